@@ -0,0 +1,68 @@
+package janua
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+// Config controls how a Client issues and verifies tokens.
+type Config struct {
+	// Issuer is the value placed in the "iss" claim of tokens minted by
+	// this SDK, and the value required of the "iss" claim on tokens it
+	// verifies.
+	Issuer string
+
+	// Audience is the default "aud" claim for minted tokens.
+	Audience string
+
+	// SigningKey is the HMAC key used to sign and verify session JWTs.
+	// Asymmetrically signed third-party ID tokens are verified by the oidc
+	// package's Verifier instead.
+	SigningKey []byte
+
+	// SessionTTL is how long a minted session token remains valid.
+	// Defaults to 1 hour when zero.
+	SessionTTL time.Duration
+}
+
+func (c Config) validate() error {
+	if c.Issuer == "" {
+		return fmt.Errorf("%w: Issuer is required", ErrInvalidConfig)
+	}
+	if len(c.SigningKey) == 0 {
+		return fmt.Errorf("%w: SigningKey is required", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// Client is the entry point for the Janua Go SDK. It owns the signing key
+// material and default claims used to issue and verify session tokens.
+type Client struct {
+	cfg          Config
+	recorder     *telemetry.Recorder
+	refreshStore RefreshStore
+}
+
+// New constructs a Client from cfg, filling in defaults for unset fields.
+// Pass WithMeter, WithTracerProvider, and/or WithMetricsRegistry to
+// instrument the client's operations without the SDK forcing a global
+// registry or tracer provider.
+func New(cfg Config, opts ...Option) (*Client, error) {
+	if cfg.SessionTTL == 0 {
+		cfg.SessionTTL = time.Hour
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	recorder, err := telemetry.NewRecorder(o.registerer, o.meter, o.tracerProvider)
+	if err != nil {
+		return nil, fmt.Errorf("janua: configure telemetry: %w", err)
+	}
+	return &Client{cfg: cfg, recorder: recorder, refreshStore: o.refreshStore}, nil
+}
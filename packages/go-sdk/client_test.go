@@ -0,0 +1,203 @@
+package janua
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testConfig() Config {
+	return Config{Issuer: "https://issuer.example.com", Audience: "test-aud", SigningKey: []byte("test-signing-key")}
+}
+
+func TestNewRequiresIssuer(t *testing.T) {
+	cfg := testConfig()
+	cfg.Issuer = ""
+	if _, err := New(cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("New with no Issuer: err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestNewRequiresSigningKey(t *testing.T) {
+	cfg := testConfig()
+	cfg.SigningKey = nil
+	if _, err := New(cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("New with no SigningKey: err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestNewDefaultsSessionTTL(t *testing.T) {
+	c, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if c.cfg.SessionTTL != time.Hour {
+		t.Errorf("SessionTTL = %v, want %v", c.cfg.SessionTTL, time.Hour)
+	}
+}
+
+func TestIssueAndVerifyTokenRoundTrips(t *testing.T) {
+	c, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	token, err := c.IssueToken("user-1", []string{"admin"}, []string{"pwd"})
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	claims, err := c.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+	if claims.Subject != "user-1" {
+		t.Errorf("Subject = %q, want user-1", claims.Subject)
+	}
+	if len(claims.Roles) != 1 || claims.Roles[0] != "admin" {
+		t.Errorf("Roles = %v, want [admin]", claims.Roles)
+	}
+}
+
+func TestVerifyTokenRejectsWrongSigningKey(t *testing.T) {
+	c, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	token, err := c.IssueToken("user-1", nil, nil)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	other := testConfig()
+	other.SigningKey = []byte("a different key")
+	c2, err := New(other)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c2.VerifyToken(token); !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("VerifyToken with wrong key: err = %v, want ErrTokenInvalid", err)
+	}
+}
+
+type stubProvider struct {
+	name     string
+	identity Identity
+	err      error
+}
+
+func (s stubProvider) Name() string { return s.name }
+
+func (s stubProvider) Authenticate(ctx context.Context, credentials map[string]string) (Identity, error) {
+	return s.identity, s.err
+}
+
+func TestAuthenticatorAuthenticatesKnownProvider(t *testing.T) {
+	client, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	auth := NewAuthenticator(client, stubProvider{name: "stub", identity: Identity{Subject: "user-1", Roles: []string{"member"}}})
+
+	identity, token, err := auth.Authenticate(context.Background(), "stub", map[string]string{})
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if identity.Provider != "stub" {
+		t.Errorf("Provider = %q, want stub", identity.Provider)
+	}
+	if token == "" {
+		t.Error("expected a non-empty session token")
+	}
+}
+
+func TestAuthenticatorRejectsUnknownProvider(t *testing.T) {
+	client, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	auth := NewAuthenticator(client)
+
+	if _, _, err := auth.Authenticate(context.Background(), "missing", nil); err == nil {
+		t.Fatal("Authenticate with an unknown provider: expected an error, got nil")
+	}
+}
+
+type stubRefreshStore struct {
+	tokens map[string]string
+	nextID int
+}
+
+func newStubRefreshStore() *stubRefreshStore {
+	return &stubRefreshStore{tokens: make(map[string]string)}
+}
+
+func (s *stubRefreshStore) Save(ctx context.Context, refreshToken string) (string, error) {
+	s.nextID++
+	id := string(rune('a' + s.nextID))
+	s.tokens[id] = refreshToken
+	return id, nil
+}
+
+func (s *stubRefreshStore) Redeem(ctx context.Context, id string) (string, error) {
+	token, ok := s.tokens[id]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	delete(s.tokens, id)
+	return token, nil
+}
+
+func (s *stubRefreshStore) Revoke(ctx context.Context, id string) error {
+	delete(s.tokens, id)
+	return nil
+}
+
+func TestIssueTokenPairRequiresRefreshStore(t *testing.T) {
+	c, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.IssueTokenPair(context.Background(), "user-1", nil, nil, "rt"); err == nil {
+		t.Fatal("IssueTokenPair without WithRefreshStore: expected an error, got nil")
+	}
+}
+
+func TestIssueTokenPairAndRedeemRoundTrips(t *testing.T) {
+	store := newStubRefreshStore()
+	c, err := New(testConfig(), WithRefreshStore(store))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	pair, err := c.IssueTokenPair(context.Background(), "user-1", nil, nil, "raw-refresh-token")
+	if err != nil {
+		t.Fatalf("IssueTokenPair: %v", err)
+	}
+	if pair.AccessToken == "" || pair.RefreshTokenID == "" {
+		t.Fatalf("IssueTokenPair returned an empty token or ID: %+v", pair)
+	}
+
+	redeemed, err := c.RedeemRefreshToken(context.Background(), pair.RefreshTokenID)
+	if err != nil {
+		t.Fatalf("RedeemRefreshToken: %v", err)
+	}
+	if redeemed != "raw-refresh-token" {
+		t.Errorf("RedeemRefreshToken = %q, want raw-refresh-token", redeemed)
+	}
+
+	if _, err := c.RedeemRefreshToken(context.Background(), pair.RefreshTokenID); err == nil {
+		t.Fatal("second RedeemRefreshToken of the same ID: expected an error, got nil")
+	}
+}
+
+func TestRevokeRefreshTokenRequiresRefreshStore(t *testing.T) {
+	c, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := c.RevokeRefreshToken(context.Background(), "some-id"); err == nil {
+		t.Fatal("RevokeRefreshToken without WithRefreshStore: expected an error, got nil")
+	}
+}
@@ -0,0 +1,5 @@
+// Package janua is the Go client and server SDK for Janua authentication
+// services. It provides JWT issuance and verification, OAuth2/OIDC helpers,
+// and session management primitives that the rest of the subpackages
+// (webauthn, oidc, ldap, sessions, realtime) build on.
+package janua
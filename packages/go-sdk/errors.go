@@ -0,0 +1,17 @@
+package janua
+
+import "errors"
+
+var (
+	// ErrInvalidConfig is returned by New when the supplied Config is
+	// missing required fields or contains inconsistent values.
+	ErrInvalidConfig = errors.New("janua: invalid config")
+
+	// ErrTokenExpired is returned when a token is syntactically valid but
+	// has passed its expiry time.
+	ErrTokenExpired = errors.New("janua: token expired")
+
+	// ErrTokenInvalid is returned when a token fails signature
+	// verification or otherwise does not satisfy the expected claims.
+	ErrTokenInvalid = errors.New("janua: token invalid")
+)
@@ -0,0 +1,81 @@
+package janua
+
+import (
+	"context"
+	"fmt"
+)
+
+// Identity is the normalized result of a successful authentication against
+// any IdentityProvider: an LDAP bind, an OIDC token exchange, a WebAuthn
+// assertion, and so on.
+type Identity struct {
+	// Subject is the stable identifier for this identity, used as the
+	// "sub" claim of any session token minted from it.
+	Subject string
+
+	// Roles are the authorization roles resolved for this identity
+	// (e.g. via the ldap package's GroupToRoleMapper).
+	Roles []string
+
+	// Provider is the name of the IdentityProvider that produced this
+	// Identity, useful for audit logging and AMR claims.
+	Provider string
+
+	// Claims carries any additional provider-specific attributes (e.g.
+	// an OIDC ID token's claims, or LDAP attributes pulled from the user
+	// entry) that callers may want to surface without threading a
+	// provider-specific type through the Authenticator.
+	Claims map[string]interface{}
+}
+
+// IdentityProvider authenticates a caller-supplied set of credentials and
+// returns the resulting Identity. Credentials are a loosely-typed bag
+// because each provider expects different inputs (an LDAP bind wants
+// "username"/"password"; the oidc package's provider wants an already
+// token-exchanged "id_token").
+type IdentityProvider interface {
+	// Name identifies the provider, used to pick it out of an
+	// Authenticator and as the Identity.Provider value.
+	Name() string
+
+	Authenticate(ctx context.Context, credentials map[string]string) (Identity, error)
+}
+
+// Authenticator composes multiple IdentityProviders behind a single entry
+// point, minting a Janua session token for whichever one successfully
+// authenticates the supplied credentials.
+type Authenticator struct {
+	client    *Client
+	providers map[string]IdentityProvider
+}
+
+// NewAuthenticator builds an Authenticator that mints session tokens via
+// client for any of the given providers.
+func NewAuthenticator(client *Client, providers ...IdentityProvider) *Authenticator {
+	a := &Authenticator{client: client, providers: make(map[string]IdentityProvider, len(providers))}
+	for _, p := range providers {
+		a.providers[p.Name()] = p
+	}
+	return a
+}
+
+// Authenticate runs credentials through the named provider and, on success,
+// mints a Janua session token carrying the resulting identity's roles.
+func (a *Authenticator) Authenticate(ctx context.Context, provider string, credentials map[string]string) (Identity, string, error) {
+	p, ok := a.providers[provider]
+	if !ok {
+		return Identity{}, "", fmt.Errorf("janua: unknown identity provider %q", provider)
+	}
+
+	identity, err := p.Authenticate(ctx, credentials)
+	if err != nil {
+		return Identity{}, "", err
+	}
+	identity.Provider = provider
+
+	token, err := a.client.IssueToken(identity.Subject, identity.Roles, []string{provider})
+	if err != nil {
+		return identity, "", fmt.Errorf("janua: issue session token: %w", err)
+	}
+	return identity, token, nil
+}
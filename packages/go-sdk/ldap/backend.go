@@ -0,0 +1,182 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	janua "github.com/madfam-org/janua/packages/go-sdk"
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+// matchingRuleInChain is the Active Directory OID that, used as a filter
+// matching rule, walks nested group membership transitively.
+const matchingRuleInChain = "1.2.840.113556.1.4.1941"
+
+// IdentityProviderName is the name this package registers itself under
+// when composed into a janua.Authenticator.
+const IdentityProviderName = "ldap"
+
+// Backend authenticates users against an LDAP/Active Directory directory
+// and resolves their group memberships into Janua roles.
+type Backend struct {
+	cfg      Config
+	pool     *connPool
+	mapper   GroupToRoleMapper
+	recorder *telemetry.Recorder
+}
+
+// NewBackend constructs a Backend from cfg, mapping resolved group DNs to
+// roles via mapper. Pass WithRecorder to instrument authentication
+// attempts.
+func NewBackend(cfg Config, mapper GroupToRoleMapper, opts ...Option) (*Backend, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if mapper == nil {
+		return nil, fmt.Errorf("ldap: GroupToRoleMapper is required")
+	}
+	o := applyOptions(opts)
+	return &Backend{cfg: cfg, pool: newConnPool(cfg), mapper: mapper, recorder: o.recorder}, nil
+}
+
+// Close releases pooled connections.
+func (b *Backend) Close() { b.pool.close() }
+
+func (b *Backend) Name() string { return IdentityProviderName }
+
+// Authenticate binds the service account, searches for credentials["username"]
+// by Config.UserFilter, rebinds as that user with credentials["password"] to
+// verify it, and resolves the user's roles from their group memberships.
+func (b *Backend) Authenticate(ctx context.Context, credentials map[string]string) (janua.Identity, error) {
+	ctx, span := b.recorder.StartSpan(ctx, "ldap.authenticate")
+	defer span.End()
+
+	identity, err := b.authenticate(credentials)
+
+	outcome := "success"
+	switch {
+	case errors.Is(err, errInvalidCredentials):
+		outcome = "denied"
+	case err != nil:
+		outcome = "error"
+	}
+	b.recorder.ObserveAuthRequest("ldap", outcome)
+
+	return identity, err
+}
+
+func (b *Backend) authenticate(credentials map[string]string) (janua.Identity, error) {
+	username := credentials["username"]
+	password := credentials["password"]
+	if username == "" || password == "" {
+		return janua.Identity{}, fmt.Errorf("ldap: credentials must include username and password")
+	}
+
+	conn, err := b.pool.get()
+	if err != nil {
+		return janua.Identity{}, err
+	}
+	healthy := true
+	defer func() { b.pool.put(conn, healthy) }()
+
+	userDN, entry, err := b.findUser(conn, username)
+	if err != nil {
+		healthy = false
+		return janua.Identity{}, err
+	}
+
+	// Rebind as the user on a separate connection so the pooled
+	// service-account connection's bind state is never disturbed.
+	if err := b.verifyPassword(userDN, password); err != nil {
+		return janua.Identity{}, err
+	}
+
+	groups := entry.GetAttributeValues(b.cfg.memberOfAttribute())
+	if b.cfg.ResolveNestedGroups {
+		nested, err := b.resolveNestedGroups(conn, userDN)
+		if err != nil {
+			healthy = false
+			return janua.Identity{}, err
+		}
+		groups = mergeUnique(groups, nested)
+	}
+
+	return janua.Identity{
+		Subject: userDN,
+		Roles:   b.mapper.MapGroups(groups),
+		Claims: map[string]interface{}{
+			"username": username,
+			"groups":   groups,
+		},
+	}, nil
+}
+
+func (b *Backend) findUser(conn *goldap.Conn, username string) (string, *goldap.Entry, error) {
+	req := goldap.NewSearchRequest(
+		b.cfg.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(b.cfg.UserFilter, goldap.EscapeFilter(username)),
+		[]string{"dn", b.cfg.memberOfAttribute()},
+		nil,
+	)
+	result, err := b.search(conn, req)
+	if err != nil {
+		return "", nil, fmt.Errorf("ldap: search for user %q: %w", username, err)
+	}
+	if len(result.Entries) != 1 {
+		return "", nil, fmt.Errorf("ldap: expected exactly one entry for user %q, got %d", username, len(result.Entries))
+	}
+	entry := result.Entries[0]
+	return entry.DN, entry, nil
+}
+
+// verifyPassword rebinds as userDN on a fresh, unpooled connection: mixing a
+// user bind into the service-account pool would leak the wrong identity to
+// whichever caller next borrows that connection.
+func (b *Backend) verifyPassword(userDN, password string) error {
+	conn, err := b.pool.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(userDN, password); err != nil {
+		return fmt.Errorf("ldap: %w", errInvalidCredentials)
+	}
+	return nil
+}
+
+func (b *Backend) resolveNestedGroups(conn *goldap.Conn, userDN string) ([]string, error) {
+	filter := fmt.Sprintf("(member:%s:=%s)", matchingRuleInChain, goldap.EscapeFilter(userDN))
+	req := goldap.NewSearchRequest(
+		b.cfg.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{"dn"},
+		nil,
+	)
+	result, err := b.search(conn, req)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: resolve nested groups for %q: %w", userDN, err)
+	}
+	groups := make([]string, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		groups = append(groups, e.DN)
+	}
+	return groups, nil
+}
+
+func mergeUnique(a, b []string) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	out := make([]string, 0, len(a)+len(b))
+	for _, s := range append(append([]string{}, a...), b...) {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
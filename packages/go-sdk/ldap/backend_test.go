@@ -0,0 +1,44 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+func testConfig() Config {
+	return Config{
+		Addrs:      []string{"127.0.0.1:0"},
+		BaseDN:     "dc=example,dc=com",
+		UserFilter: "(sAMAccountName=%s)",
+	}
+}
+
+func TestNewBackendWiresRecorderOption(t *testing.T) {
+	rec, err := telemetry.NewRecorder(nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	b, err := NewBackend(testConfig(), StaticGroupToRoleMapper{}, WithRecorder(rec))
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	if b.recorder != rec {
+		t.Fatal("NewBackend did not wire the recorder from WithRecorder")
+	}
+}
+
+func TestAuthenticateRequiresCredentials(t *testing.T) {
+	b, err := NewBackend(testConfig(), StaticGroupToRoleMapper{})
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+
+	// No recorder configured: ObserveAuthRequest/StartSpan must be
+	// nil-receiver-safe rather than panicking.
+	if _, err := b.Authenticate(context.Background(), map[string]string{"username": "alice"}); err == nil {
+		t.Fatal("Authenticate with no password: expected an error")
+	}
+}
@@ -0,0 +1,117 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+)
+
+// Config controls how Backend connects to and searches the directory.
+type Config struct {
+	// Addrs is the set of directory server addresses ("host:port") the
+	// connection pool dials, in priority order.
+	Addrs []string
+
+	// UseLDAPS dials Addrs over implicit TLS (ldaps://, typically :636).
+	// Mutually exclusive with UseStartTLS.
+	UseLDAPS bool
+
+	// UseStartTLS upgrades a plaintext connection (typically :389) with
+	// StartTLS before any bind is attempted.
+	UseStartTLS bool
+
+	// TLSConfig is used for both UseLDAPS and UseStartTLS connections. A
+	// nil value uses Go's default TLS configuration.
+	TLSConfig *tls.Config
+
+	// BindDN and BindPassword are the service account credentials used to
+	// search the directory before rebinding as the authenticating user.
+	BindDN       string
+	BindPassword string
+
+	// BaseDN is the search base for user and group lookups.
+	BaseDN string
+
+	// UserFilter is an LDAP filter template with a single %s verb for the
+	// submitted username, e.g. "(&(objectClass=user)(sAMAccountName=%s))".
+	UserFilter string
+
+	// MemberOfAttribute is the attribute holding a user's direct group
+	// DNs, typically "memberOf".
+	MemberOfAttribute string
+
+	// ResolveNestedGroups, when true, also resolves group memberships
+	// inherited through nested groups using Active Directory's
+	// LDAP_MATCHING_RULE_IN_CHAIN OID, in addition to the user's direct
+	// MemberOfAttribute values.
+	ResolveNestedGroups bool
+
+	// ChaseReferrals, when true, follows any LDAP referrals a search
+	// response returns by dialing the referred server and reissuing the
+	// same search there, merging the results. Referral hops are bounded
+	// (see maxReferralHops) so a misbehaving directory can't chase
+	// forever. A referral is only followed if its host:port is also
+	// listed in Addrs; referrals to any other host are rejected rather
+	// than dialed, since a compromised or MITM'd directory could
+	// otherwise use a referral to capture the service account's
+	// credentials on a server the operator never configured. Dialing a
+	// referral always uses Addrs' own UseLDAPS/UseStartTLS/TLSConfig
+	// policy, never whatever scheme the referral URL specifies.
+	ChaseReferrals bool
+
+	// PoolSize is the number of pooled connections to the directory.
+	// Defaults to 4 when zero.
+	PoolSize int
+
+	// DialTimeout bounds how long dialing a directory server may take.
+	// Defaults to 10s when zero.
+	DialTimeout time.Duration
+}
+
+func (c Config) validate() error {
+	if len(c.Addrs) == 0 {
+		return fmt.Errorf("ldap: at least one address is required")
+	}
+	if c.UseLDAPS && c.UseStartTLS {
+		return fmt.Errorf("ldap: UseLDAPS and UseStartTLS are mutually exclusive")
+	}
+	if c.BaseDN == "" {
+		return fmt.Errorf("ldap: BaseDN is required")
+	}
+	if c.UserFilter == "" {
+		return fmt.Errorf("ldap: UserFilter is required")
+	}
+	return nil
+}
+
+func (c Config) poolSize() int {
+	if c.PoolSize <= 0 {
+		return 4
+	}
+	return c.PoolSize
+}
+
+func (c Config) dialTimeout() time.Duration {
+	if c.DialTimeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.DialTimeout
+}
+
+// addrAllowed reports whether addr ("host:port") is one of the operator-
+// configured Addrs, used to gate which hosts a referral may be chased to.
+func (c Config) addrAllowed(addr string) bool {
+	for _, a := range c.Addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Config) memberOfAttribute() string {
+	if c.MemberOfAttribute == "" {
+		return "memberOf"
+	}
+	return c.MemberOfAttribute
+}
@@ -0,0 +1,10 @@
+// Package ldap federates Janua authentication against an LDAP or Active
+// Directory directory: it binds a service account, searches for the
+// authenticating user's DN, rebinds as that user to verify the password,
+// and maps the user's group memberships into Janua roles via a pluggable
+// GroupToRoleMapper.
+//
+// Backend implements janua.IdentityProvider so it can be composed with
+// other identity sources (e.g. oidc.IdentityProvider) behind a single
+// janua.Authenticator.
+package ldap
@@ -0,0 +1,7 @@
+package ldap
+
+import "errors"
+
+// errInvalidCredentials is returned (wrapped) when the user bind used to
+// verify a password fails.
+var errInvalidCredentials = errors.New("invalid credentials")
@@ -0,0 +1,26 @@
+package ldap
+
+// GroupToRoleMapper translates a user's resolved group DNs into the Janua
+// roles baked into their session token.
+type GroupToRoleMapper interface {
+	MapGroups(groupDNs []string) []string
+}
+
+// StaticGroupToRoleMapper maps group DNs to roles via a fixed lookup table.
+// Groups with no matching entry contribute no roles.
+type StaticGroupToRoleMapper map[string][]string
+
+func (m StaticGroupToRoleMapper) MapGroups(groupDNs []string) []string {
+	seen := make(map[string]struct{})
+	var roles []string
+	for _, dn := range groupDNs {
+		for _, role := range m[dn] {
+			if _, ok := seen[role]; ok {
+				continue
+			}
+			seen[role] = struct{}{}
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
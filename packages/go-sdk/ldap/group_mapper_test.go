@@ -0,0 +1,44 @@
+package ldap
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestStaticGroupToRoleMapperDeduplicatesRoles(t *testing.T) {
+	mapper := StaticGroupToRoleMapper{
+		"cn=admins,dc=example,dc=com": {"admin", "user"},
+		"cn=staff,dc=example,dc=com":  {"user"},
+	}
+
+	roles := mapper.MapGroups([]string{
+		"cn=admins,dc=example,dc=com",
+		"cn=staff,dc=example,dc=com",
+		"cn=unmapped,dc=example,dc=com",
+	})
+
+	sort.Strings(roles)
+	want := []string{"admin", "user"}
+	if !reflect.DeepEqual(roles, want) {
+		t.Errorf("MapGroups = %v, want %v", roles, want)
+	}
+}
+
+func TestStaticGroupToRoleMapperNoMatches(t *testing.T) {
+	mapper := StaticGroupToRoleMapper{"cn=admins,dc=example,dc=com": {"admin"}}
+	if roles := mapper.MapGroups([]string{"cn=nobody,dc=example,dc=com"}); len(roles) != 0 {
+		t.Errorf("MapGroups = %v, want empty", roles)
+	}
+}
+
+func TestMergeUniqueDropsDuplicatesAndKeepsOrder(t *testing.T) {
+	got := mergeUnique(
+		[]string{"cn=a,dc=example,dc=com", "cn=b,dc=example,dc=com"},
+		[]string{"cn=b,dc=example,dc=com", "cn=c,dc=example,dc=com"},
+	)
+	want := []string{"cn=a,dc=example,dc=com", "cn=b,dc=example,dc=com", "cn=c,dc=example,dc=com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeUnique = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// connPool is a small fixed-size pool of bound service-account connections.
+// Connections are lazily dialed on first use and redialed if a borrowed
+// connection turns out to be dead.
+type connPool struct {
+	cfg Config
+
+	mu   sync.Mutex
+	idle []*goldap.Conn
+}
+
+func newConnPool(cfg Config) *connPool {
+	return &connPool{cfg: cfg}
+}
+
+func (p *connPool) dial() (*goldap.Conn, error) {
+	if len(p.cfg.Addrs) == 0 {
+		return nil, fmt.Errorf("ldap: no addresses configured")
+	}
+
+	var lastErr error
+	for _, addr := range p.cfg.Addrs {
+		conn, err := p.dialOne(addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+	return nil, fmt.Errorf("ldap: failed to dial any configured server: %w", lastErr)
+}
+
+func (p *connPool) dialOne(addr string) (*goldap.Conn, error) {
+	var conn *goldap.Conn
+	var err error
+
+	switch {
+	case p.cfg.UseLDAPS:
+		conn, err = goldap.DialURL(fmt.Sprintf("ldaps://%s", addr), goldap.DialWithTLSConfig(tlsConfig(p.cfg)))
+	default:
+		conn, err = goldap.DialURL(fmt.Sprintf("ldap://%s", addr))
+		if err == nil && p.cfg.UseStartTLS {
+			if tlsErr := conn.StartTLS(tlsConfig(p.cfg)); tlsErr != nil {
+				conn.Close()
+				return nil, fmt.Errorf("ldap: starttls to %s: %w", addr, tlsErr)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap: dial %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+func tlsConfig(cfg Config) *tls.Config {
+	if cfg.TLSConfig != nil {
+		return cfg.TLSConfig
+	}
+	return &tls.Config{}
+}
+
+// get borrows a bound service-account connection from the pool, dialing and
+// binding a new one if the pool is empty.
+func (p *connPool) get() (*goldap.Conn, error) {
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		conn := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		return conn, nil
+	}
+	p.mu.Unlock()
+
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Bind(p.cfg.BindDN, p.cfg.BindPassword); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ldap: service account bind: %w", err)
+	}
+	return conn, nil
+}
+
+// put returns a connection to the pool, or closes it if the pool is full or
+// the connection is no longer usable.
+func (p *connPool) put(conn *goldap.Conn, healthy bool) {
+	if !healthy || conn.IsClosing() {
+		conn.Close()
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.idle) >= p.cfg.poolSize() {
+		conn.Close()
+		return
+	}
+	p.idle = append(p.idle, conn)
+}
+
+func (p *connPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.idle {
+		conn.Close()
+	}
+	p.idle = nil
+}
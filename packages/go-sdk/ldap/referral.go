@@ -0,0 +1,123 @@
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// maxReferralHops bounds how many referrals a single search follows, so a
+// misconfigured or malicious directory can't send us chasing referrals
+// forever.
+const maxReferralHops = 5
+
+// errReferralHostNotAllowed is returned when a directory-supplied referral
+// points at a host that isn't one of Config.Addrs. Referrals are attacker-
+// or MITM-influenceable, so the service account is never rebound to a host
+// the operator didn't explicitly configure.
+var errReferralHostNotAllowed = errors.New("ldap: referral host is not in Config.Addrs")
+
+// search runs req against conn and, when Config.ChaseReferrals is set,
+// follows any referrals the server returns, merging their entries into the
+// result. Referral searches use a fresh, unpooled connection bound with the
+// same service account credentials, since a referral can point at a
+// different server entirely.
+func (b *Backend) search(conn *goldap.Conn, req *goldap.SearchRequest) (*goldap.SearchResult, error) {
+	result, err := conn.Search(req)
+	if err != nil {
+		return result, err
+	}
+	if !b.cfg.ChaseReferrals || len(result.Referrals) == 0 {
+		return result, nil
+	}
+
+	seen := make(map[string]struct{}, len(result.Referrals))
+	for hops := 0; hops < maxReferralHops; hops++ {
+		referrals := result.Referrals
+		result.Referrals = nil
+		if len(referrals) == 0 {
+			break
+		}
+
+		for _, referral := range referrals {
+			if _, ok := seen[referral]; ok {
+				continue
+			}
+			seen[referral] = struct{}{}
+
+			chased, err := b.chaseReferral(referral, req)
+			if err != nil {
+				return nil, fmt.Errorf("ldap: chase referral %q: %w", referral, err)
+			}
+			result.Entries = append(result.Entries, chased.Entries...)
+			result.Referrals = append(result.Referrals, chased.Referrals...)
+		}
+	}
+	return result, nil
+}
+
+// chaseReferral reissues req against the server named by referral (an LDAP
+// URL), using the referral's base DN when it supplies one.
+//
+// The referral's host must appear in Config.Addrs: a referral is returned
+// by the directory itself (and so is attacker- or MITM-influenceable), so
+// neither its host nor its scheme is trusted. We dial via the pool's own
+// dialOne, which always applies the operator's configured
+// UseLDAPS/UseStartTLS/TLSConfig policy, never whatever the referral URL
+// asked for, and only then rebind the real service-account credentials.
+func (b *Backend) chaseReferral(referral string, req *goldap.SearchRequest) (*goldap.SearchResult, error) {
+	u, err := url.Parse(referral)
+	if err != nil {
+		return nil, fmt.Errorf("parse referral URL: %w", err)
+	}
+
+	addr := referralAddr(u, b.cfg)
+	if !b.cfg.addrAllowed(addr) {
+		return nil, fmt.Errorf("%w: %s", errReferralHostNotAllowed, addr)
+	}
+
+	conn, err := b.pool.dialOne(addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial referral server %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(b.cfg.BindDN, b.cfg.BindPassword); err != nil {
+		return nil, fmt.Errorf("bind to referral server: %w", err)
+	}
+
+	baseDN := strings.TrimPrefix(u.Path, "/")
+	if baseDN == "" {
+		baseDN = req.BaseDN
+	}
+
+	referredReq := goldap.NewSearchRequest(
+		baseDN,
+		req.Scope, req.DerefAliases, req.SizeLimit, req.TimeLimit, req.TypesOnly,
+		req.Filter,
+		req.Attributes,
+		nil,
+	)
+	return conn.Search(referredReq)
+}
+
+// referralAddr extracts the "host:port" a referral URL names, defaulting
+// the port from cfg's TLS policy when the referral omits one. The
+// referral's scheme (ldap/ldaps) is deliberately ignored here: whether we
+// use TLS is Config's decision, not the directory's.
+func referralAddr(u *url.URL, cfg Config) string {
+	host := u.Hostname()
+	port := u.Port()
+	if port == "" {
+		if cfg.UseLDAPS {
+			port = "636"
+		} else {
+			port = "389"
+		}
+	}
+	return net.JoinHostPort(host, port)
+}
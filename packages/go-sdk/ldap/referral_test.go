@@ -0,0 +1,60 @@
+package ldap
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// TestChaseReferralRejectsUnlistedHost is a regression test for a referral
+// attack: a directory (or a MITM able to inject a referral response) must
+// not be able to redirect the service-account bind to a host the operator
+// didn't configure in Addrs.
+func TestChaseReferralRejectsUnlistedHost(t *testing.T) {
+	cfg := testConfig()
+	cfg.Addrs = []string{"ldap.internal.example.com:389"}
+	b := &Backend{cfg: cfg, pool: newConnPool(cfg)}
+
+	req := goldap.NewSearchRequest(cfg.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false, "(objectClass=*)", nil, nil)
+
+	_, err := b.chaseReferral("ldap://attacker.example.com/dc=example,dc=com", req)
+	if !errors.Is(err, errReferralHostNotAllowed) {
+		t.Fatalf("chaseReferral to an unlisted host: err = %v, want errReferralHostNotAllowed", err)
+	}
+}
+
+func TestAddrAllowedAcceptsOnlyListedHosts(t *testing.T) {
+	cfg := testConfig()
+	cfg.Addrs = []string{"ldap.internal.example.com:389"}
+
+	if !cfg.addrAllowed("ldap.internal.example.com:389") {
+		t.Error("addrAllowed should accept a host:port listed in Addrs")
+	}
+	if cfg.addrAllowed("attacker.example.com:389") {
+		t.Error("addrAllowed should reject a host:port not listed in Addrs")
+	}
+}
+
+func TestReferralAddrDefaultsPortFromTLSPolicy(t *testing.T) {
+	tests := []struct {
+		referral string
+		useLDAPS bool
+		want     string
+	}{
+		{"ldap://host.example.com/dc=example,dc=com", false, "host.example.com:389"},
+		{"ldaps://host.example.com/dc=example,dc=com", true, "host.example.com:636"},
+		{"ldap://host.example.com:1389/dc=example,dc=com", false, "host.example.com:1389"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.referral)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.referral, err)
+		}
+		cfg := Config{UseLDAPS: tt.useLDAPS}
+		if got := referralAddr(u, cfg); got != tt.want {
+			t.Errorf("referralAddr(%q) = %q, want %q", tt.referral, got, tt.want)
+		}
+	}
+}
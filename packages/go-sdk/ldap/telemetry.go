@@ -0,0 +1,26 @@
+package ldap
+
+import "github.com/madfam-org/janua/packages/go-sdk/telemetry"
+
+// Option configures optional behavior shared across this package's
+// constructors.
+type Option func(*options)
+
+type options struct {
+	recorder *telemetry.Recorder
+}
+
+// WithRecorder instruments each Backend.Authenticate call with one of rec's
+// OpenTelemetry spans and one authentication-request observation. The binds
+// and searches inside a call are not instrumented separately.
+func WithRecorder(rec *telemetry.Recorder) Option {
+	return func(o *options) { o.recorder = rec }
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ProviderConfig is the subset of the OpenID Provider Metadata document
+// (OIDC Discovery 1.0 §3) the SDK uses.
+type ProviderConfig struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	UserinfoEndpoint                 string   `json:"userinfo_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ScopesSupported                  []string `json:"scopes_supported"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Discover fetches and parses the issuer's
+// /.well-known/openid-configuration document.
+func Discover(ctx context.Context, httpClient *http.Client, issuer string) (ProviderConfig, error) {
+	var cfg ProviderConfig
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	wellKnown, err := wellKnownURL(issuer)
+	if err != nil {
+		return cfg, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return cfg, fmt.Errorf("oidc: build discovery request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return cfg, fmt.Errorf("oidc: fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return cfg, fmt.Errorf("oidc: discovery document returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("oidc: decode discovery document: %w", err)
+	}
+	if cfg.Issuer != issuer {
+		return cfg, fmt.Errorf("oidc: discovery document issuer %q does not match requested issuer %q", cfg.Issuer, issuer)
+	}
+	return cfg, nil
+}
+
+func wellKnownURL(issuer string) (string, error) {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return "", fmt.Errorf("oidc: invalid issuer URL: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/.well-known/openid-configuration"
+	return u.String(), nil
+}
@@ -0,0 +1,41 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverFetchesWellKnownDocument(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		base := "http://" + r.Host
+		fmt.Fprintf(w, `{"issuer":%q,"authorization_endpoint":"%s/auth","token_endpoint":"%s/token","jwks_uri":"%s/jwks"}`,
+			base, base, base, base)
+	}))
+	defer ts.Close()
+
+	cfg, err := Discover(context.Background(), ts.Client(), ts.URL)
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if cfg.TokenEndpoint != ts.URL+"/token" {
+		t.Errorf("TokenEndpoint = %q, want %q", cfg.TokenEndpoint, ts.URL+"/token")
+	}
+}
+
+func TestDiscoverRejectsIssuerMismatch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"issuer":"https://wrong-issuer.example.com"}`)
+	}))
+	defer ts.Close()
+
+	if _, err := Discover(context.Background(), ts.Client(), ts.URL); err == nil {
+		t.Fatal("Discover: expected an issuer-mismatch error, got nil")
+	}
+}
@@ -0,0 +1,8 @@
+// Package oidc extends golang.org/x/oauth2 with OpenID Connect support:
+// provider discovery, a self-refreshing JWKS cache, ID token verification,
+// and a PKCE-enabled authorization code flow helper.
+//
+// The Verifier type shares its signature-validation code path with the
+// janua package's JWT layer so that self-issued Janua tokens and
+// third-party OIDC ID tokens can be checked through the same primitives.
+package oidc
@@ -0,0 +1,124 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+// AuthCodeFlow drives a PKCE-protected OAuth2 authorization code flow and
+// returns a parsed OIDC identity alongside the raw token response.
+type AuthCodeFlow struct {
+	oauth2Config oauth2.Config
+	verifier     *Verifier
+	recorder     *telemetry.Recorder
+}
+
+// NewAuthCodeFlow builds an AuthCodeFlow from provider discovery metadata,
+// client credentials, and the redirect URL registered with the provider.
+// Pass WithRecorder to instrument the code exchange.
+func NewAuthCodeFlow(cfg ProviderConfig, clientID, clientSecret, redirectURL string, scopes []string, verifier *Verifier, opts ...Option) *AuthCodeFlow {
+	o := applyOptions(opts)
+	return &AuthCodeFlow{
+		oauth2Config: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  cfg.AuthorizationEndpoint,
+				TokenURL: cfg.TokenEndpoint,
+			},
+		},
+		verifier: verifier,
+		recorder: o.recorder,
+	}
+}
+
+// AuthRequest bundles the values a caller must persist (e.g. in a signed
+// cookie) between issuing the authorization URL and handling the redirect
+// callback.
+type AuthRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	AuthCodeURL  string
+}
+
+// BeginAuthCodeFlow generates state, nonce, and a PKCE pair, and returns the
+// URL to redirect the user-agent to.
+func (f *AuthCodeFlow) BeginAuthCodeFlow() (AuthRequest, error) {
+	state, err := generateRandomString(32)
+	if err != nil {
+		return AuthRequest{}, err
+	}
+	nonce, err := generateRandomString(32)
+	if err != nil {
+		return AuthRequest{}, err
+	}
+	pkce, err := NewPKCE()
+	if err != nil {
+		return AuthRequest{}, err
+	}
+
+	url := f.oauth2Config.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("code_challenge", pkce.CodeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	)
+
+	return AuthRequest{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: pkce.CodeVerifier,
+		AuthCodeURL:  url,
+	}, nil
+}
+
+// Identity is the verified identity extracted from the token response's ID
+// token, plus the raw tokens for callers that need to call the userinfo
+// endpoint or otherwise hold onto the access/refresh tokens.
+type Identity struct {
+	Claims *IDTokenClaims
+	Tokens *oauth2.Token
+}
+
+// Finish exchanges the authorization code for tokens and verifies the
+// resulting ID token against req (which must be the AuthRequest returned by
+// the matching BeginAuthCodeFlow call for this state).
+func (f *AuthCodeFlow) Finish(ctx context.Context, req AuthRequest, gotState, code string) (*Identity, error) {
+	ctx, span := f.recorder.StartSpan(ctx, "oidc.exchange")
+	defer span.End()
+
+	if gotState != req.State {
+		return nil, fmt.Errorf("oidc: state mismatch")
+	}
+
+	tokens, err := f.oauth2Config.Exchange(ctx, code,
+		oauth2.SetAuthURLParam("code_verifier", req.CodeVerifier),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := tokens.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("oidc: token response did not include an id_token")
+	}
+
+	claims, err := f.verifier.Verify(ctx, rawIDToken, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyAccessTokenHash(claims, tokens.AccessToken); err != nil {
+		return nil, err
+	}
+	if err := VerifyCodeHash(claims, code); err != nil {
+		return nil, err
+	}
+
+	return &Identity{Claims: claims, Tokens: tokens}, nil
+}
@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	janua "github.com/madfam-org/janua/packages/go-sdk"
+)
+
+// IdentityProviderName is the name this package registers itself under
+// when composed into a janua.Authenticator.
+const IdentityProviderName = "oidc"
+
+// IdentityProvider adapts Verifier to janua.IdentityProvider for callers
+// composing multiple identity sources behind a single Authenticator. It
+// expects the authorization code flow to already have run (see
+// AuthCodeFlow) and credentials to carry the resulting raw ID token and the
+// nonce that was used to request it.
+type IdentityProvider struct {
+	verifier *Verifier
+}
+
+// NewIdentityProvider wraps verifier as a janua.IdentityProvider.
+func NewIdentityProvider(verifier *Verifier) *IdentityProvider {
+	return &IdentityProvider{verifier: verifier}
+}
+
+func (p *IdentityProvider) Name() string { return IdentityProviderName }
+
+// Authenticate verifies credentials["id_token"] (using credentials["nonce"]
+// if present) and returns the resulting identity.
+func (p *IdentityProvider) Authenticate(ctx context.Context, credentials map[string]string) (janua.Identity, error) {
+	rawIDToken := credentials["id_token"]
+	if rawIDToken == "" {
+		return janua.Identity{}, fmt.Errorf("oidc: credentials must include id_token")
+	}
+
+	claims, err := p.verifier.Verify(ctx, rawIDToken, credentials["nonce"])
+	if err != nil {
+		return janua.Identity{}, err
+	}
+
+	return janua.Identity{
+		Subject: claims.Subject,
+		Claims: map[string]interface{}{
+			"iss":   claims.Issuer,
+			"aud":   claims.Audience,
+			"nonce": claims.Nonce,
+		},
+	}, nil
+}
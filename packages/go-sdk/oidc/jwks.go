@@ -0,0 +1,267 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultJWKSRefresh is used when the JWKS response does not send a
+// Cache-Control max-age directive.
+const defaultJWKSRefresh = 15 * time.Minute
+
+// minJWKSRefresh bounds how often we'll honor a Cache-Control max-age that
+// is implausibly low, to avoid hammering the provider.
+const minJWKSRefresh = 1 * time.Minute
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// JWKSCache fetches and caches a provider's JSON Web Key Set, refreshing it
+// in the background on a schedule derived from the response's Cache-Control
+// header. It also forces an out-of-band refetch when asked for a kid it
+// doesn't recognize, to pick up keys rotated since the last scheduled
+// refresh.
+type JWKSCache struct {
+	jwksURI    string
+	httpClient *http.Client
+
+	mu              sync.RWMutex
+	keys            map[string]interface{}
+	fetched         time.Time
+	refreshInterval time.Duration
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewJWKSCache constructs a cache for the given JWKS endpoint. Call Start to
+// begin background refreshing; Get works without Start too, fetching
+// lazily on first use.
+func NewJWKSCache(jwksURI string, httpClient *http.Client) *JWKSCache {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &JWKSCache{
+		jwksURI:    jwksURI,
+		httpClient: httpClient,
+		keys:       make(map[string]interface{}),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start launches a background goroutine that refreshes the key set on the
+// interval the provider advertises via Cache-Control, until ctx is done or
+// Close is called.
+func (c *JWKSCache) Start(ctx context.Context) {
+	go func() {
+		interval := defaultJWKSRefresh
+		if err := c.refresh(ctx); err == nil {
+			interval = c.nextInterval()
+		}
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.stop:
+				return
+			case <-timer.C:
+				if err := c.refresh(ctx); err == nil {
+					interval = c.nextInterval()
+				}
+				timer.Reset(interval)
+			}
+		}
+	}()
+}
+
+// Close stops the background refresher started by Start.
+func (c *JWKSCache) Close() {
+	c.once.Do(func() { close(c.stop) })
+}
+
+// Get returns the public key for kid, forcing a synchronous refetch if kid
+// is not currently known (it may belong to a key rotated in since our last
+// refresh).
+func (c *JWKSCache) Get(ctx context.Context, kid string) (interface{}, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	c.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("oidc: refresh jwks after kid miss: %w", err)
+	}
+
+	c.mu.RLock()
+	key, ok = c.keys[kid]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("oidc: no key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+var jwksCacheControlMaxAge = parseCacheControlMaxAge // indirection for testability
+
+func (c *JWKSCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.jwksURI, nil)
+	if err != nil {
+		return fmt.Errorf("oidc: build jwks request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("oidc: fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: jwks endpoint returned status %d", resp.StatusCode)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("oidc: decode jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		pub, err := k.publicKey()
+		if err != nil {
+			continue // skip key types we don't understand rather than fail the whole set
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetched = time.Now()
+	c.refreshInterval = jwksCacheControlMaxAge(resp.Header.Get("Cache-Control"))
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *JWKSCache) nextInterval() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.refreshInterval <= 0 {
+		return defaultJWKSRefresh
+	}
+	if c.refreshInterval < minJWKSRefresh {
+		return minJWKSRefresh
+	}
+	return c.refreshInterval
+}
+
+func parseCacheControlMaxAge(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	for _, directive := range splitComma(header) {
+		const prefix = "max-age="
+		if len(directive) > len(prefix) && directive[:len(prefix)] == prefix {
+			if secs, err := strconv.Atoi(directive[len(prefix):]); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+	return 0
+}
+
+func splitComma(s string) []string {
+	var out []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == ',' {
+			out = append(out, trimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	out = append(out, trimSpace(s[start:]))
+	return out
+}
+
+func trimSpace(s string) string {
+	for len(s) > 0 && s[0] == ' ' {
+		s = s[1:]
+	}
+	for len(s) > 0 && s[len(s)-1] == ' ' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		curve, err := ellipticCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("oidc: unsupported key type %q", k.Kty)
+	}
+}
+
+func ellipticCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("oidc: unsupported curve %q", crv)
+	}
+}
@@ -0,0 +1,38 @@
+package oidc
+
+import "testing"
+
+func TestParseCacheControlMaxAge(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int
+	}{
+		{"", 0},
+		{"max-age=300", 300},
+		{"no-cache", 0},
+		{"public, max-age=900, must-revalidate", 900},
+		{"max-age=not-a-number", 0},
+	}
+
+	for _, tt := range tests {
+		got := parseCacheControlMaxAge(tt.header)
+		if int(got.Seconds()) != tt.want {
+			t.Errorf("parseCacheControlMaxAge(%q) = %v, want %ds", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestJWKSCacheNextIntervalFallsBackToDefault(t *testing.T) {
+	c := NewJWKSCache("https://example.com/jwks", nil)
+	if got := c.nextInterval(); got != defaultJWKSRefresh {
+		t.Errorf("nextInterval with no prior fetch = %v, want %v", got, defaultJWKSRefresh)
+	}
+}
+
+func TestJWKSCacheNextIntervalClampsToMinimum(t *testing.T) {
+	c := NewJWKSCache("https://example.com/jwks", nil)
+	c.refreshInterval = minJWKSRefresh / 2
+	if got := c.nextInterval(); got != minJWKSRefresh {
+		t.Errorf("nextInterval with a too-low Cache-Control max-age = %v, want %v", got, minJWKSRefresh)
+	}
+}
@@ -0,0 +1,38 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// generateRandomString returns a cryptographically random, URL-safe string
+// suitable for a PKCE code_verifier, state, or nonce value.
+func generateRandomString(byteLen int) (string, error) {
+	b := make([]byte, byteLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("oidc: generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// PKCE holds a generated code_verifier and its S256 code_challenge, per
+// RFC 7636.
+type PKCE struct {
+	CodeVerifier  string
+	CodeChallenge string
+}
+
+// NewPKCE generates a fresh code_verifier and its S256 code_challenge.
+func NewPKCE() (PKCE, error) {
+	verifier, err := generateRandomString(32)
+	if err != nil {
+		return PKCE{}, err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return PKCE{
+		CodeVerifier:  verifier,
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+)
+
+func TestNewPKCEChallengeMatchesVerifier(t *testing.T) {
+	pkce, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE: %v", err)
+	}
+	if pkce.CodeVerifier == "" {
+		t.Fatal("CodeVerifier is empty")
+	}
+
+	sum := sha256.Sum256([]byte(pkce.CodeVerifier))
+	want := base64.RawURLEncoding.EncodeToString(sum[:])
+	if pkce.CodeChallenge != want {
+		t.Errorf("CodeChallenge = %q, want %q", pkce.CodeChallenge, want)
+	}
+}
+
+func TestNewPKCEGeneratesDistinctVerifiers(t *testing.T) {
+	a, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE: %v", err)
+	}
+	b, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE: %v", err)
+	}
+	if a.CodeVerifier == b.CodeVerifier {
+		t.Error("two NewPKCE calls produced the same code_verifier")
+	}
+}
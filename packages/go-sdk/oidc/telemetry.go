@@ -0,0 +1,26 @@
+package oidc
+
+import "github.com/madfam-org/janua/packages/go-sdk/telemetry"
+
+// Option configures optional behavior shared across this package's
+// constructors.
+type Option func(*options)
+
+type options struct {
+	recorder *telemetry.Recorder
+}
+
+// WithRecorder instruments this package's OAuth2/OIDC exchanges and ID
+// token verifications with rec's Prometheus metrics and OpenTelemetry
+// spans.
+func WithRecorder(rec *telemetry.Recorder) Option {
+	return func(o *options) { o.recorder = rec }
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
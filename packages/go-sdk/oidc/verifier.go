@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	janua "github.com/madfam-org/janua/packages/go-sdk"
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+// IDTokenClaims is the set of standard OIDC ID token claims the Verifier
+// checks. Other claims the provider includes are not retained.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+
+	Nonce  string `json:"nonce,omitempty"`
+	AtHash string `json:"at_hash,omitempty"`
+	CHash  string `json:"c_hash,omitempty"`
+}
+
+// Verifier validates OIDC ID tokens against a provider's JWKS, and
+// optionally against an access token / authorization code via at_hash /
+// c_hash.
+type Verifier struct {
+	issuer   string
+	audience string
+	jwks     *JWKSCache
+	recorder *telemetry.Recorder
+}
+
+// NewVerifier constructs a Verifier for tokens issued by cfg.Issuer,
+// expected to carry audience, resolving signing keys from jwks. Pass
+// WithRecorder to instrument verifications.
+func NewVerifier(cfg ProviderConfig, audience string, jwks *JWKSCache, opts ...Option) *Verifier {
+	o := applyOptions(opts)
+	return &Verifier{issuer: cfg.Issuer, audience: audience, jwks: jwks, recorder: o.recorder}
+}
+
+// Verify validates rawIDToken's signature, iss, aud, exp, and nbf, and, when
+// nonce is non-empty, that the token's nonce claim matches. It returns the
+// parsed claims on success.
+func (v *Verifier) Verify(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	ctx, span := v.recorder.StartSpan(ctx, "oidc.verify")
+	defer span.End()
+	start := time.Now()
+
+	claims := &IDTokenClaims{}
+	_, err := janua.ParseSigned(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, fmt.Errorf("oidc: id token is missing a kid header")
+		}
+		return v.jwks.Get(ctx, kid)
+	}, jwt.WithIssuer(v.issuer), jwt.WithAudience(v.audience))
+	if err == nil && nonce != "" && claims.Nonce != nonce {
+		err = fmt.Errorf("oidc: nonce mismatch")
+	}
+
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	v.recorder.ObserveJWTVerifyDuration(outcome, time.Since(start))
+	v.recorder.ObserveAuthRequest("oidc", outcome)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// VerifyAccessTokenHash checks the token's at_hash claim against
+// accessToken, per the OIDC Core spec §3.1.3.6. It always computes at_hash
+// with SHA-256, matching RS256/ES256 ID tokens.
+func VerifyAccessTokenHash(claims *IDTokenClaims, accessToken string) error {
+	if claims.AtHash == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(accessToken))
+	want := base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+	if want != claims.AtHash {
+		return fmt.Errorf("oidc: at_hash does not match access token")
+	}
+	return nil
+}
+
+// VerifyCodeHash checks the token's c_hash claim against the authorization
+// code, per the OIDC Core spec §3.3.2.11.
+func VerifyCodeHash(claims *IDTokenClaims, code string) error {
+	if claims.CHash == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(code))
+	want := base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+	if want != claims.CHash {
+		return fmt.Errorf("oidc: c_hash does not match authorization code")
+	}
+	return nil
+}
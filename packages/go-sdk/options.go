@@ -0,0 +1,46 @@
+package janua
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures optional Client behavior not covered by Config, such as
+// observability backends and server-side refresh token storage.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	registerer     prometheus.Registerer
+	meter          metric.Meter
+	tracerProvider trace.TracerProvider
+	refreshStore   RefreshStore
+}
+
+// WithMetricsRegistry registers the SDK's Prometheus collectors
+// (janua_auth_requests_total, janua_jwt_verify_duration_seconds,
+// janua_ws_active_connections, ...) with reg instead of leaving them
+// unregistered.
+func WithMetricsRegistry(reg prometheus.Registerer) Option {
+	return func(o *clientOptions) { o.registerer = reg }
+}
+
+// WithMeter configures OpenTelemetry metric instruments recorded alongside
+// (or instead of) the Prometheus collectors.
+func WithMeter(meter metric.Meter) Option {
+	return func(o *clientOptions) { o.meter = meter }
+}
+
+// WithTracerProvider configures the OpenTelemetry TracerProvider used to
+// emit spans for outbound exchanges and verifications. Without this option
+// spans are created against the OpenTelemetry no-op tracer.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *clientOptions) { o.tracerProvider = tp }
+}
+
+// WithRefreshStore enables IssueTokenPair/RedeemRefreshToken/RevokeRefreshToken
+// by configuring where refresh tokens are persisted server-side. The
+// sessions package's RefreshTokenStore is the intended implementation.
+func WithRefreshStore(store RefreshStore) Option {
+	return func(o *clientOptions) { o.refreshStore = store }
+}
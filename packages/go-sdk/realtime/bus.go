@@ -0,0 +1,17 @@
+package realtime
+
+import "context"
+
+// EventBus publishes Events and lets the Hub subscribe to a single user's
+// stream, regardless of how many Hub instances (and therefore processes)
+// are running behind a load balancer.
+type EventBus interface {
+	// Publish broadcasts event to every current Subscribe call for
+	// event.UserID, across all processes sharing this bus.
+	Publish(ctx context.Context, event Event) error
+
+	// Subscribe returns a channel of events for userID and an unsubscribe
+	// function the caller must invoke when done. The channel is closed
+	// after unsubscribe is called; canceling ctx does not close it.
+	Subscribe(ctx context.Context, userID string) (<-chan Event, func(), error)
+}
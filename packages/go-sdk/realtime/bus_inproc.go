@@ -0,0 +1,56 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// InProcBus fans out events to subscribers within the same process. It's
+// the right choice for a single-instance deployment or tests; anything
+// running more than one replica needs RedisBus or NATSBus instead so
+// publishes from one instance reach subscribers connected to another.
+type InProcBus struct {
+	mu   sync.Mutex
+	subs map[string]map[chan Event]struct{}
+}
+
+// NewInProcBus constructs an empty InProcBus.
+func NewInProcBus() *InProcBus {
+	return &InProcBus{subs: make(map[string]map[chan Event]struct{})}
+}
+
+func (b *InProcBus) Publish(ctx context.Context, event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[event.UserID] {
+		select {
+		case ch <- event:
+		default:
+			// Drop rather than block a slow subscriber; reconnects use
+			// last-event-id to catch up via the Hub's history buffer.
+		}
+	}
+	return nil
+}
+
+func (b *InProcBus) Subscribe(ctx context.Context, userID string) (<-chan Event, func(), error) {
+	ch := make(chan Event, 16)
+
+	b.mu.Lock()
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[chan Event]struct{})
+	}
+	b.subs[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		delete(b.subs[userID], ch)
+		if len(b.subs[userID]) == 0 {
+			delete(b.subs, userID)
+		}
+		b.mu.Unlock()
+		close(ch)
+	}
+	return ch, unsubscribe, nil
+}
@@ -0,0 +1,71 @@
+package realtime
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSBus fans out events via NATS core pub/sub subjects, one subject per
+// user. It's a good fit for deployments that already run NATS for other
+// service-to-service messaging and don't want a separate Redis dependency
+// just for this.
+type NATSBus struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+// NewNATSBus constructs a NATSBus. subjectPrefix namespaces subjects (e.g.
+// "janua.realtime.").
+func NewNATSBus(conn *nats.Conn, subjectPrefix string) *NATSBus {
+	return &NATSBus{conn: conn, subjectPrefix: subjectPrefix}
+}
+
+// subject builds the per-user NATS subject. NATS subjects are hierarchical,
+// using "." as a token separator and "*"/">" as wildcards, so a userID
+// containing any of those would turn Subscribe/Publish into a wildcard
+// match against other users' subjects under the same prefix. userID is hex
+// encoded so the resulting subject token can never contain subject syntax,
+// regardless of what the user identifier looks like.
+func (b *NATSBus) subject(userID string) string {
+	return b.subjectPrefix + hex.EncodeToString([]byte(userID))
+}
+
+func (b *NATSBus) Publish(ctx context.Context, event Event) error {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("realtime: encode event: %w", err)
+	}
+	if err := b.conn.Publish(b.subject(event.UserID), raw); err != nil {
+		return fmt.Errorf("realtime: publish event: %w", err)
+	}
+	return nil
+}
+
+func (b *NATSBus) Subscribe(ctx context.Context, userID string) (<-chan Event, func(), error) {
+	out := make(chan Event, 16)
+
+	sub, err := b.conn.Subscribe(b.subject(userID), func(msg *nats.Msg) {
+		var event Event
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			return
+		}
+		select {
+		case out <- event:
+		default:
+		}
+	})
+	if err != nil {
+		close(out)
+		return nil, nil, fmt.Errorf("realtime: subscribe: %w", err)
+	}
+
+	unsubscribe := func() {
+		_ = sub.Unsubscribe()
+		close(out)
+	}
+	return out, unsubscribe, nil
+}
@@ -0,0 +1,38 @@
+package realtime
+
+import "testing"
+
+// TestNATSBusSubjectEscapesWildcards is a regression test for a cross-user
+// event leak: a userID containing NATS subject syntax ("*", ">", ".") must
+// not be able to turn Publish/Subscribe into a wildcard match against other
+// users' events under the same prefix.
+func TestNATSBusSubjectEscapesWildcards(t *testing.T) {
+	b := &NATSBus{subjectPrefix: "janua.realtime."}
+
+	wildcard := b.subject("*")
+	other := b.subject("alice")
+	if wildcard == "janua.realtime.*" {
+		t.Fatalf("subject(%q) = %q, a literal NATS wildcard token", "*", wildcard)
+	}
+	if wildcard == other {
+		t.Fatalf("subject(%q) and subject(%q) collided: %q", "*", "alice", wildcard)
+	}
+
+	dotted := b.subject("alice.bob")
+	if dotted == "janua.realtime.alice.bob" {
+		t.Fatalf("subject(%q) = %q, contains an unescaped subject separator", "alice.bob", dotted)
+	}
+}
+
+func TestNATSBusSubjectIsInjective(t *testing.T) {
+	b := &NATSBus{subjectPrefix: "janua.realtime."}
+	ids := []string{"alice", "bob", "*", ">", "alice.bob", "a*b", ""}
+	seen := make(map[string]string, len(ids))
+	for _, id := range ids {
+		s := b.subject(id)
+		if prior, ok := seen[s]; ok {
+			t.Fatalf("subject(%q) and subject(%q) both produced %q", id, prior, s)
+		}
+		seen[s] = id
+	}
+}
@@ -0,0 +1,75 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBus fans out events via Redis Pub/Sub, so publishes from any
+// instance reach subscribers connected to any other instance.
+type RedisBus struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisBus constructs a RedisBus. keyPrefix namespaces the pub/sub
+// channels (e.g. "janua:realtime:").
+func NewRedisBus(client redis.UniversalClient, keyPrefix string) *RedisBus {
+	return &RedisBus{client: client, keyPrefix: keyPrefix}
+}
+
+func (b *RedisBus) channel(userID string) string { return b.keyPrefix + userID }
+
+func (b *RedisBus) Publish(ctx context.Context, event Event) error {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("realtime: encode event: %w", err)
+	}
+	if err := b.client.Publish(ctx, b.channel(event.UserID), raw).Err(); err != nil {
+		return fmt.Errorf("realtime: publish event: %w", err)
+	}
+	return nil
+}
+
+func (b *RedisBus) Subscribe(ctx context.Context, userID string) (<-chan Event, func(), error) {
+	pubsub := b.client.Subscribe(ctx, b.channel(userID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, nil, fmt.Errorf("realtime: subscribe: %w", err)
+	}
+
+	out := make(chan Event, 16)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(out)
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-done:
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				var event Event
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					continue
+				}
+				select {
+				case out <- event:
+				default:
+				}
+			}
+		}
+	}()
+
+	unsubscribe := func() {
+		close(done)
+		pubsub.Close()
+	}
+	return out, unsubscribe, nil
+}
@@ -0,0 +1,205 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// pongWait bounds how long we wait for a pong after sending a ping before
+// considering the connection dead.
+const pongWait = 60 * time.Second
+
+// Conn is one authenticated realtime connection for a single user. It owns
+// the read and write pumps required by gorilla/websocket (exactly one
+// goroutine may call Conn.WriteMessage at a time) and exposes a channel-safe
+// way for the Hub to push frames to the client.
+type Conn struct {
+	userID string
+	ws     *websocket.Conn
+	hub    *Hub
+
+	send chan Frame
+
+	mu        sync.Mutex
+	pending   map[string]chan ChallengeResponse
+	heartbeat time.Duration
+}
+
+func newConn(userID string, ws *websocket.Conn, hub *Hub, heartbeat time.Duration) *Conn {
+	return &Conn{
+		userID:    userID,
+		ws:        ws,
+		hub:       hub,
+		send:      make(chan Frame, 32),
+		pending:   make(map[string]chan ChallengeResponse),
+		heartbeat: heartbeat,
+	}
+}
+
+// run drives the connection until ctx is canceled or the client
+// disconnects. It subscribes to the Hub's EventBus for this connection's
+// user and blocks until the connection ends.
+func (c *Conn) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, unsubscribe, err := c.hub.subscribe(ctx, c.userID)
+	if err != nil {
+		c.ws.Close()
+		return
+	}
+	defer unsubscribe()
+
+	if c.hub.recorder != nil {
+		c.hub.recorder.IncWSActiveConnections()
+		defer c.hub.recorder.DecWSActiveConnections()
+	}
+
+	go c.readPump(ctx, cancel)
+	go c.forwardEvents(ctx, events)
+
+	c.writePump(ctx)
+}
+
+// forwardEvents relays events from the Hub's EventBus subscription onto the
+// connection's send channel as "event" frames.
+func (c *Conn) forwardEvents(ctx context.Context, events <-chan Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			frame, err := marshalFrame(FrameEvent, event.ID, event)
+			if err != nil {
+				continue
+			}
+			select {
+			case c.send <- frame:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// PushChallenge sends an interactive challenge down to the client (e.g. a
+// step-up WebAuthn assertion request) and blocks until the client responds
+// or ctx is done.
+func (c *Conn) PushChallenge(ctx context.Context, challenge Challenge) (ChallengeResponse, error) {
+	id := uuid.NewString()
+	frame, err := marshalFrame(FrameChallenge, id, challenge)
+	if err != nil {
+		return ChallengeResponse{}, err
+	}
+
+	reply := make(chan ChallengeResponse, 1)
+	c.mu.Lock()
+	c.pending[id] = reply
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	select {
+	case c.send <- frame:
+	case <-ctx.Done():
+		return ChallengeResponse{}, ctx.Err()
+	}
+
+	select {
+	case resp := <-reply:
+		return resp, nil
+	case <-ctx.Done():
+		return ChallengeResponse{}, ctx.Err()
+	}
+}
+
+func (c *Conn) writePump(ctx context.Context) {
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+	defer c.ws.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case frame, ok := <-c.send:
+			if !ok {
+				return
+			}
+			if err := c.ws.WriteJSON(frame); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.ws.WriteJSON(Frame{Type: FramePing}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *Conn) readPump(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	c.ws.SetPongHandler(func(string) error {
+		c.ws.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		var frame Frame
+		if err := c.ws.ReadJSON(&frame); err != nil {
+			return
+		}
+		c.ws.SetReadDeadline(time.Now().Add(pongWait))
+
+		switch frame.Type {
+		case FramePong:
+			// handled by SetPongHandler for control-frame pongs; a JSON
+			// pong frame is also accepted from clients that can't send
+			// WebSocket control frames directly.
+		case FrameResume:
+			var req ResumeRequest
+			if err := json.Unmarshal(frame.Data, &req); err != nil {
+				continue
+			}
+			for _, event := range c.hub.eventsSince(c.userID, req.LastEventID) {
+				replay, err := marshalFrame(FrameEvent, event.ID, event)
+				if err != nil {
+					continue
+				}
+				select {
+				case c.send <- replay:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case FrameChallengeResponse:
+			var resp ChallengeResponse
+			if err := json.Unmarshal(frame.Data, &resp); err != nil {
+				continue
+			}
+			c.mu.Lock()
+			reply, ok := c.pending[frame.ID]
+			c.mu.Unlock()
+			if ok {
+				reply <- resp
+			}
+		default:
+			// Unknown frame types are ignored rather than closing the
+			// connection, so adding new frame types stays
+			// backwards-compatible with older clients.
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	janua "github.com/madfam-org/janua/packages/go-sdk"
+)
+
+type stubVerifier struct{ subject string }
+
+func (s stubVerifier) VerifyToken(raw string) (*janua.Claims, error) {
+	claims := &janua.Claims{}
+	claims.Subject = s.subject
+	return claims, nil
+}
+
+// TestResumeReplayDoesNotLeakOnSlowClient is a regression test for a
+// deadlock in readPump's FrameResume handling: replaying a backlog larger
+// than the send buffer into a client that isn't draining it used to block
+// readPump forever, even after the connection's context was canceled,
+// leaking the goroutine. It must now give up once ctx is done.
+func TestResumeReplayDoesNotLeakOnSlowClient(t *testing.T) {
+	hub := NewHub(NewInProcBus(), WithHistoryLimit(128))
+	ctx := context.Background()
+
+	first := Event{UserID: "user-1", Type: EventSessionTerminated}
+	if err := hub.Publish(ctx, first); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	baseline := hub.history["user-1"][0].ID
+
+	// Publish far more events than the connection's send buffer (32) can
+	// hold, so replaying the backlog must block on a full channel.
+	for i := 0; i < 64; i++ {
+		if err := hub.Publish(ctx, Event{UserID: "user-1", Type: EventSessionTerminated}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	server := NewServer(hub, stubVerifier{subject: "user-1"}, WithHeartbeat(time.Hour))
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "?access_token=test"
+	before := runtime.NumGoroutine()
+
+	client, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	if err := client.WriteJSON(Frame{Type: FrameResume, Data: mustJSON(t, ResumeRequest{LastEventID: baseline})}); err != nil {
+		t.Fatalf("write resume frame: %v", err)
+	}
+
+	// Never drain the replay: close the connection immediately to simulate
+	// a client that vanished mid-replay.
+	time.Sleep(50 * time.Millisecond)
+	client.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if runtime.NumGoroutine() <= before+1 {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("goroutine count stayed elevated (before=%d, after=%d); readPump likely leaked", before, runtime.NumGoroutine())
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return raw
+}
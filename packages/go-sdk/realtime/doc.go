@@ -0,0 +1,11 @@
+// Package realtime exposes a bidirectional WebSocket event stream so
+// applications can subscribe to a user's auth events (token revocation,
+// session termination, MFA challenges, consent changes) and push
+// interactive challenges (e.g. a step-up WebAuthn assertion request) down
+// to a connected client.
+//
+// A server-side Hub fans out events published to a pluggable EventBus
+// (in-process, Redis pub/sub, or NATS) to every connection registered for
+// the target user, enabling immediate cross-tab logout without polling.
+// Connections authenticate once, on the upgrade handshake, via a Janua JWT.
+package realtime
@@ -0,0 +1,28 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event types the SDK defines out of the box. Applications may publish
+// additional custom types; the Hub treats Event.Type as an opaque string.
+const (
+	EventTokenRevoked         = "token.revoked"
+	EventSessionTerminated    = "session.terminated"
+	EventMFAChallengeRequired = "mfa.challenge_required"
+	EventConsentChanged       = "consent.changed"
+)
+
+// Event is a single auth event scoped to a user, published on an EventBus
+// and fanned out to that user's connected clients.
+type Event struct {
+	// ID is a unique identifier (a random UUID when Hub.Publish assigns
+	// it) used as the protocol's last-event-id for reconnect resumption.
+	ID string `json:"id"`
+
+	UserID  string          `json:"userId"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Time    time.Time       `json:"time"`
+}
@@ -0,0 +1,104 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+// Hub fans out events published via its EventBus to every connection
+// registered for the target user, and keeps a short per-user history so a
+// reconnecting client can resume from its last-seen event ID instead of
+// missing events that were published while it was offline.
+type Hub struct {
+	bus      EventBus
+	recorder *telemetry.Recorder
+
+	historyLimit int
+	historyTTL   time.Duration
+	mu           sync.Mutex
+	history      map[string][]Event
+}
+
+// NewHub constructs a Hub that publishes through bus.
+func NewHub(bus EventBus, opts ...Option) *Hub {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Hub{bus: bus, recorder: o.recorder, historyLimit: o.historyLimit, historyTTL: o.historyTTL, history: make(map[string][]Event)}
+}
+
+// Publish assigns event an ID and a timestamp where unset, records it in the
+// user's history, and broadcasts it via the EventBus to every connected
+// instance. Publishing also opportunistically evicts any other user's
+// history that has aged out past historyTTL, so a Hub that has published
+// for many distinct users over its lifetime doesn't retain all of them
+// forever.
+func (h *Hub) Publish(ctx context.Context, event Event) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	h.mu.Lock()
+	hist := append(h.history[event.UserID], event)
+	if len(hist) > h.historyLimit {
+		hist = hist[len(hist)-h.historyLimit:]
+	}
+	h.history[event.UserID] = hist
+	h.evictExpired(event.Time)
+	h.mu.Unlock()
+
+	if err := h.bus.Publish(ctx, event); err != nil {
+		return fmt.Errorf("realtime: publish event: %w", err)
+	}
+	return nil
+}
+
+// evictExpired removes any user's history whose most recent event is older
+// than historyTTL relative to now. Callers must hold h.mu.
+func (h *Hub) evictExpired(now time.Time) {
+	if h.historyTTL <= 0 {
+		return
+	}
+	cutoff := now.Add(-h.historyTTL)
+	for userID, hist := range h.history {
+		if len(hist) == 0 || hist[len(hist)-1].Time.Before(cutoff) {
+			delete(h.history, userID)
+		}
+	}
+}
+
+// eventsSince returns the events recorded for userID after lastEventID. If
+// lastEventID is empty or not found in history (e.g. the server restarted),
+// it returns nil, signaling the caller that no replay is possible and only
+// new events going forward will be delivered.
+func (h *Hub) eventsSince(userID, lastEventID string) []Event {
+	if lastEventID == "" {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist := h.history[userID]
+	for i, e := range hist {
+		if e.ID == lastEventID {
+			return append([]Event(nil), hist[i+1:]...)
+		}
+	}
+	return nil
+}
+
+// subscribe is a thin pass-through to the EventBus, kept on Hub so Conn
+// doesn't need a separate reference to the bus.
+func (h *Hub) subscribe(ctx context.Context, userID string) (<-chan Event, func(), error) {
+	return h.bus.Subscribe(ctx, userID)
+}
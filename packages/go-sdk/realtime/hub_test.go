@@ -0,0 +1,83 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHubEventsSinceReplaysAfterLastEventID(t *testing.T) {
+	hub := NewHub(NewInProcBus(), WithHistoryLimit(10))
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		event := Event{UserID: "user-1", Type: EventSessionTerminated}
+		if err := hub.Publish(ctx, event); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		ids = append(ids, hub.history["user-1"][len(hub.history["user-1"])-1].ID)
+	}
+
+	replay := hub.eventsSince("user-1", ids[1])
+	if len(replay) != 3 {
+		t.Fatalf("expected 3 events after %s, got %d", ids[1], len(replay))
+	}
+	for i, event := range replay {
+		if event.ID != ids[2+i] {
+			t.Errorf("replay[%d] = %s, want %s", i, event.ID, ids[2+i])
+		}
+	}
+}
+
+func TestHubEventsSinceUnknownIDReturnsNil(t *testing.T) {
+	hub := NewHub(NewInProcBus())
+	if got := hub.eventsSince("user-1", "does-not-exist"); got != nil {
+		t.Errorf("eventsSince with unknown ID = %v, want nil", got)
+	}
+	if got := hub.eventsSince("user-1", ""); got != nil {
+		t.Errorf("eventsSince with empty lastEventID = %v, want nil", got)
+	}
+}
+
+func TestHubHistoryRespectsLimit(t *testing.T) {
+	hub := NewHub(NewInProcBus(), WithHistoryLimit(3))
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		if err := hub.Publish(ctx, Event{UserID: "user-1"}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	if got := len(hub.history["user-1"]); got != 3 {
+		t.Fatalf("history length = %d, want 3", got)
+	}
+}
+
+// TestHubEvictsExpiredUserHistory is a regression test for an
+// unbounded-over-time memory leak: a user's history entry must eventually
+// be evicted once it ages out past historyTTL, even though it never grew
+// beyond the per-user historyLimit.
+func TestHubEvictsExpiredUserHistory(t *testing.T) {
+	hub := NewHub(NewInProcBus(), WithHistoryTTL(time.Millisecond))
+	ctx := context.Background()
+
+	if err := hub.Publish(ctx, Event{UserID: "stale-user"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if _, ok := hub.history["stale-user"]; !ok {
+		t.Fatal("expected stale-user to be present in history right after publishing")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	// Publishing for a different user should sweep stale-user's now-expired
+	// entry out of the map.
+	if err := hub.Publish(ctx, Event{UserID: "other-user"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if _, ok := hub.history["stale-user"]; ok {
+		t.Fatal("expected stale-user's history to have been evicted after historyTTL elapsed")
+	}
+}
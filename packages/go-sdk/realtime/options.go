@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"time"
+
+	"github.com/madfam-org/janua/packages/go-sdk/telemetry"
+)
+
+// defaultHeartbeat is how often the server sends a ping frame and expects a
+// pong back before considering a connection dead.
+const defaultHeartbeat = 30 * time.Second
+
+// defaultHistoryLimit bounds how many recent events per user the Hub keeps
+// in memory to replay to a reconnecting client's "resume" request.
+const defaultHistoryLimit = 100
+
+// defaultHistoryTTL bounds how long a user's history is kept once published,
+// regardless of how few events it holds. Without this, a Hub that has ever
+// published for a given user keeps that user's map entry forever, even
+// after every connection for that user has long since disconnected and any
+// plausible reconnect window has passed — an unbounded-over-time leak keyed
+// on distinct users ever seen, not active users.
+const defaultHistoryTTL = 10 * time.Minute
+
+// Option configures a Hub or Server.
+type Option func(*options)
+
+type options struct {
+	recorder     *telemetry.Recorder
+	heartbeat    time.Duration
+	historyLimit int
+	historyTTL   time.Duration
+}
+
+func defaultOptions() options {
+	return options{heartbeat: defaultHeartbeat, historyLimit: defaultHistoryLimit, historyTTL: defaultHistoryTTL}
+}
+
+// WithRecorder records the number of active connections on rec. It takes
+// effect when passed to NewHub; NewServer does not use it. Individual
+// messages are not counted.
+func WithRecorder(rec *telemetry.Recorder) Option {
+	return func(o *options) { o.recorder = rec }
+}
+
+// WithHeartbeat overrides the default 30s ping interval.
+func WithHeartbeat(d time.Duration) Option {
+	return func(o *options) { o.heartbeat = d }
+}
+
+// WithHistoryLimit overrides how many recent events per user the Hub
+// retains for reconnect resumption (default 100).
+func WithHistoryLimit(n int) Option {
+	return func(o *options) { o.historyLimit = n }
+}
+
+// WithHistoryTTL overrides how long a user's event history is kept once
+// published before it's evicted, regardless of how few events it holds
+// (default 10m). This bounds Hub memory use by elapsed time rather than
+// only by per-user slice length, so users who stop reconnecting don't
+// accumulate forever.
+func WithHistoryTTL(d time.Duration) Option {
+	return func(o *options) { o.historyTTL = d }
+}
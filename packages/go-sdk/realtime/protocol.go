@@ -0,0 +1,61 @@
+package realtime
+
+import "encoding/json"
+
+// Frame is the small JSON envelope every message on the wire uses,
+// regardless of direction.
+type Frame struct {
+	// Type identifies the frame's purpose: "event", "ping", "pong",
+	// "challenge", "challenge_response", or "resume".
+	Type string `json:"type"`
+
+	// ID is the event ID for "event" frames (used as last-event-id on
+	// reconnect) or a correlation ID for "challenge"/"challenge_response"
+	// pairs. Absent on "ping"/"pong".
+	ID string `json:"id,omitempty"`
+
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Frame types.
+const (
+	FrameEvent             = "event"
+	FramePing              = "ping"
+	FramePong              = "pong"
+	FrameChallenge         = "challenge"
+	FrameChallengeResponse = "challenge_response"
+	FrameResume            = "resume"
+)
+
+// Challenge is the payload of a "challenge" frame: an interactive request
+// pushed to a connected client, e.g. a step-up WebAuthn assertion request.
+type Challenge struct {
+	// Kind identifies the challenge type (e.g. "webauthn.assertion").
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ChallengeResponse is the payload of a "challenge_response" frame sent
+// back by the client.
+type ChallengeResponse struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ResumeRequest is the payload of a "resume" frame a reconnecting client
+// sends to ask for any events published since LastEventID.
+type ResumeRequest struct {
+	LastEventID string `json:"lastEventId"`
+}
+
+func marshalFrame(frameType, id string, payload interface{}) (Frame, error) {
+	var data json.RawMessage
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return Frame{}, err
+		}
+		data = raw
+	}
+	return Frame{Type: frameType, ID: id, Data: data}, nil
+}
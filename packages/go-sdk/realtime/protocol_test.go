@@ -0,0 +1,28 @@
+package realtime
+
+import "testing"
+
+func TestMarshalFrameEncodesEvent(t *testing.T) {
+	event := Event{ID: "evt-1", UserID: "user-1", Type: EventTokenRevoked}
+
+	frame, err := marshalFrame(FrameEvent, event.ID, event)
+	if err != nil {
+		t.Fatalf("marshalFrame: %v", err)
+	}
+	if frame.Type != FrameEvent || frame.ID != "evt-1" {
+		t.Fatalf("frame = %+v, want type=%s id=evt-1", frame, FrameEvent)
+	}
+	if len(frame.Data) == 0 {
+		t.Fatal("frame.Data is empty")
+	}
+}
+
+func TestMarshalFrameNilPayload(t *testing.T) {
+	frame, err := marshalFrame(FramePing, "", nil)
+	if err != nil {
+		t.Fatalf("marshalFrame: %v", err)
+	}
+	if frame.Data != nil {
+		t.Fatalf("frame.Data = %v, want nil", frame.Data)
+	}
+}
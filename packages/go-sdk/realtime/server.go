@@ -0,0 +1,80 @@
+package realtime
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	janua "github.com/madfam-org/janua/packages/go-sdk"
+)
+
+// TokenVerifier verifies the JWT presented on the upgrade handshake and
+// returns its claims; the connection is bound to their subject.
+// *janua.Client satisfies this via VerifyToken.
+type TokenVerifier interface {
+	VerifyToken(raw string) (*janua.Claims, error)
+}
+
+// Server upgrades authenticated HTTP requests to realtime WebSocket
+// connections bound to a Hub.
+type Server struct {
+	hub      *Hub
+	verifier TokenVerifier
+	upgrader websocket.Upgrader
+	o        options
+}
+
+// NewServer constructs a Server that authenticates upgrades via verifier
+// and registers connections with hub.
+func NewServer(hub *Hub, verifier TokenVerifier, opts ...Option) *Server {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Server{
+		hub:      hub,
+		verifier: verifier,
+		upgrader: websocket.Upgrader{},
+		o:        o,
+	}
+}
+
+// ServeHTTP authenticates the request via an "access_token" query parameter
+// or "Authorization: Bearer <token>" header (WebSocket upgrade requests
+// from browsers cannot set arbitrary headers before the handshake
+// completes, so the query parameter form exists for that case), upgrades
+// the connection, and runs it until the client disconnects or the request
+// context is canceled.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw := bearerToken(r)
+	if raw == "" {
+		http.Error(w, "missing access token", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := s.verifier.VerifyToken(raw)
+	if err != nil {
+		http.Error(w, "invalid access token", http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	conn := newConn(claims.Subject, ws, s.hub, s.o.heartbeat)
+	conn.run(r.Context())
+}
+
+func bearerToken(r *http.Request) string {
+	if tok := r.URL.Query().Get("access_token"); tok != "" {
+		return tok
+	}
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) > len(prefix) && auth[:len(prefix)] == prefix {
+		return auth[len(prefix):]
+	}
+	return ""
+}
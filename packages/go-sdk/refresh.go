@@ -0,0 +1,63 @@
+package janua
+
+import (
+	"context"
+	"fmt"
+)
+
+// RefreshStore persists opaque refresh tokens server-side so that only a
+// random ID, not the token itself, needs to be handed to the browser. The
+// sessions package's RefreshTokenStore satisfies this interface.
+type RefreshStore interface {
+	Save(ctx context.Context, refreshToken string) (id string, err error)
+	Redeem(ctx context.Context, id string) (string, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+// TokenPair is the result of IssueTokenPair: a short-lived access token and
+// the ID a caller should store (typically in an httpOnly cookie) to later
+// redeem the corresponding refresh token via RedeemRefreshToken.
+type TokenPair struct {
+	AccessToken    string
+	RefreshTokenID string
+}
+
+// IssueTokenPair mints an access token for subject via IssueToken and
+// stores refreshToken server-side via the Client's configured RefreshStore,
+// returning both the access token and the opaque ID that redeems the
+// refresh token later. It requires a RefreshStore to have been configured
+// with WithRefreshStore.
+func (c *Client) IssueTokenPair(ctx context.Context, subject string, roles, amr []string, refreshToken string) (TokenPair, error) {
+	if c.refreshStore == nil {
+		return TokenPair{}, fmt.Errorf("janua: IssueTokenPair requires a RefreshStore (see WithRefreshStore)")
+	}
+
+	accessToken, err := c.IssueToken(subject, roles, amr)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshID, err := c.refreshStore.Save(ctx, refreshToken)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("janua: save refresh token: %w", err)
+	}
+	return TokenPair{AccessToken: accessToken, RefreshTokenID: refreshID}, nil
+}
+
+// RedeemRefreshToken resolves refreshTokenID to the refresh token
+// previously stored by IssueTokenPair, consuming it in the process.
+func (c *Client) RedeemRefreshToken(ctx context.Context, refreshTokenID string) (string, error) {
+	if c.refreshStore == nil {
+		return "", fmt.Errorf("janua: RedeemRefreshToken requires a RefreshStore (see WithRefreshStore)")
+	}
+	return c.refreshStore.Redeem(ctx, refreshTokenID)
+}
+
+// RevokeRefreshToken invalidates refreshTokenID without redeeming it, e.g.
+// on logout.
+func (c *Client) RevokeRefreshToken(ctx context.Context, refreshTokenID string) error {
+	if c.refreshStore == nil {
+		return fmt.Errorf("janua: RevokeRefreshToken requires a RefreshStore (see WithRefreshStore)")
+	}
+	return c.refreshStore.Revoke(ctx, refreshTokenID)
+}
@@ -0,0 +1,102 @@
+package sessions
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// sealedSeparator joins the two parts of a sealed token,
+// "<keyID>.<base64url(nonce||ciphertext)>". Prefixing the
+// key ID lets Decrypt jump straight to the right key instead of trying
+// every configured key in turn.
+const sealedSeparator = "."
+
+// Encrypt seals plaintext under ks's current primary key, returning an
+// opaque token safe to store in a cookie, Redis, or an in-memory map.
+func (ks *KeySet) Encrypt(plaintext []byte) (string, error) {
+	ks.mu.RLock()
+	primary := ks.primary
+	ks.mu.RUnlock()
+
+	aead, err := chacha20poly1305.NewX(primary.Secret[:])
+	if err != nil {
+		return "", fmt.Errorf("sessions: init AEAD: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("sessions: generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+	return primary.ID + sealedSeparator + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a token produced by Encrypt. reissue is true when the token
+// was sealed under a secondary (non-primary) key, signaling the caller
+// should re-encrypt and replace the stored/cookied value with a fresh token
+// sealed under the current primary key.
+func (ks *KeySet) Decrypt(token string) (plaintext []byte, reissue bool, err error) {
+	keyID, encoded, ok := strings.Cut(token, sealedSeparator)
+	if !ok {
+		return nil, false, fmt.Errorf("sessions: malformed token")
+	}
+
+	key, ok := ks.keyByID(keyID)
+	if !ok {
+		return nil, false, fmt.Errorf("sessions: unknown key id %q", keyID)
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false, fmt.Errorf("sessions: decode token: %w", err)
+	}
+
+	aead, err := chacha20poly1305.NewX(key.Secret[:])
+	if err != nil {
+		return nil, false, fmt.Errorf("sessions: init AEAD: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, false, fmt.Errorf("sessions: truncated token")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err = aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("sessions: decrypt token: %w", err)
+	}
+
+	ks.mu.RLock()
+	isPrimary := keyID == ks.primary.ID
+	ks.mu.RUnlock()
+	return plaintext, !isPrimary, nil
+}
+
+// sealValues JSON-encodes values and seals them under keys, for
+// server-side Store implementations that persist Session.Values outside
+// the process (Redis) or in a form that could otherwise leak through a
+// heap dump (MemoryStore).
+func sealValues(keys *KeySet, values map[string]interface{}) (string, error) {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("sessions: encode session values: %w", err)
+	}
+	return keys.Encrypt(raw)
+}
+
+// openSealedValues is sealValues' inverse.
+func openSealedValues(keys *KeySet, sealed string) (values map[string]interface{}, reissue bool, err error) {
+	raw, reissue, err := keys.Decrypt(sealed)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, false, fmt.Errorf("sessions: decode session values: %w", err)
+	}
+	return values, reissue, nil
+}
@@ -0,0 +1,47 @@
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CookieStore seals an entire Session into the cookie value itself (no
+// server-side storage), in the style of gorilla/sessions' CookieStore. It
+// is a good fit for small, non-sensitive session payloads; anything that
+// must be revocable server-side (refresh tokens, in particular) belongs in
+// MemoryStore or RedisStore instead.
+type CookieStore struct {
+	keys *KeySet
+}
+
+// NewCookieStore constructs a CookieStore sealing payloads with keys.
+func NewCookieStore(keys *KeySet) *CookieStore {
+	return &CookieStore{keys: keys}
+}
+
+// Encode seals session into an opaque cookie value.
+func (c *CookieStore) Encode(session *Session) (string, error) {
+	raw, err := json.Marshal(session)
+	if err != nil {
+		return "", fmt.Errorf("sessions: encode session: %w", err)
+	}
+	return c.keys.Encrypt(raw)
+}
+
+// Decode opens a cookie value produced by Encode. reissue is true when the
+// token was sealed under a non-primary key and the caller should call
+// Encode again and replace the cookie with the new value.
+func (c *CookieStore) Decode(token string) (session *Session, reissue bool, err error) {
+	raw, reissue, err := c.keys.Decrypt(token)
+	if err != nil {
+		return nil, false, err
+	}
+	var s Session
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, false, fmt.Errorf("sessions: decode session: %w", err)
+	}
+	if s.Expired() {
+		return nil, false, ErrNotFound
+	}
+	return &s, reissue, nil
+}
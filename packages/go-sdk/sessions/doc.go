@@ -0,0 +1,6 @@
+// Package sessions provides an encrypted, pluggable session store in the
+// spirit of gorilla/sessions and securecookie: a Store interface with
+// in-memory, Redis, and cookie-backed implementations, AEAD encryption via
+// XChaCha20-Poly1305, and a KeySet that supports rotating encryption keys
+// without invalidating sessions that were encrypted under an older key.
+package sessions
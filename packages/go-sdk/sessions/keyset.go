@@ -0,0 +1,76 @@
+package sessions
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// KeyLen is the required length of a KeySet key, matching
+// XChaCha20-Poly1305's key size.
+const KeyLen = chacha20poly1305.KeySize
+
+// Key is a single named encryption key. ID is embedded in every sealed
+// payload so Decrypt can tell which key to try without attempting every
+// configured key in turn.
+type Key struct {
+	ID     string
+	Secret [KeyLen]byte
+}
+
+// GenerateKey creates a new random Key identified by id.
+func GenerateKey(id string) (Key, error) {
+	var k Key
+	k.ID = id
+	if _, err := rand.Read(k.Secret[:]); err != nil {
+		return Key{}, fmt.Errorf("sessions: generate key: %w", err)
+	}
+	return k, nil
+}
+
+// KeySet holds the active encryption/signing keys for a Store or
+// CookieStore. Exactly one key is primary and used for all new Encrypt
+// calls; any number of secondary keys remain valid for Decrypt so that
+// sessions sealed before a rotation keep working until they naturally
+// expire.
+//
+// Operators rotate keys by generating a new Key, making it primary, and
+// demoting the previous primary to secondary. Once enough time has passed
+// that no live session could still be sealed under a retired key, it can be
+// dropped from the secondary list entirely. A KeySet is safe for concurrent
+// use: Encrypt/Decrypt run on every request through Middleware while an
+// operator may call Rotate at any time.
+type KeySet struct {
+	mu        sync.RWMutex
+	primary   Key
+	secondary []Key
+}
+
+// NewKeySet builds a KeySet with primary as the sole active key.
+func NewKeySet(primary Key) *KeySet {
+	return &KeySet{primary: primary}
+}
+
+// Rotate demotes the current primary to secondary and installs newPrimary.
+func (ks *KeySet) Rotate(newPrimary Key) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.secondary = append([]Key{ks.primary}, ks.secondary...)
+	ks.primary = newPrimary
+}
+
+func (ks *KeySet) keyByID(id string) (Key, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if ks.primary.ID == id {
+		return ks.primary, true
+	}
+	for _, k := range ks.secondary {
+		if k.ID == id {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
@@ -0,0 +1,88 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store backed by a map. It is suitable for
+// single-instance deployments and tests; sessions do not survive a restart
+// and are not shared across replicas.
+type MemoryStore struct {
+	keys *KeySet
+
+	mu       sync.RWMutex
+	sessions map[string]*sealedEntry
+}
+
+// sealedEntry is what MemoryStore actually keeps in its map: Session.Values
+// encrypted under keys, alongside the plaintext metadata a store needs to
+// index and expire entries without decrypting them.
+type sealedEntry struct {
+	expiresAt time.Time
+	sealed    string
+}
+
+// NewMemoryStore constructs an empty MemoryStore. Session values are sealed
+// with keys before being held in memory, so a heap dump or debug endpoint
+// can't leak a live refresh token.
+func NewMemoryStore(keys *KeySet) *MemoryStore {
+	return &MemoryStore{keys: keys, sessions: make(map[string]*sealedEntry)}
+}
+
+func (m *MemoryStore) Get(ctx context.Context, id string) (*Session, error) {
+	m.mu.RLock()
+	entry, ok := m.sessions[id]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.mu.Lock()
+		delete(m.sessions, id)
+		m.mu.Unlock()
+		return nil, ErrNotFound
+	}
+
+	values, reissue, err := openSealedValues(m.keys, entry.sealed)
+	if err != nil {
+		return nil, fmt.Errorf("sessions: decrypt session: %w", err)
+	}
+	if reissue {
+		m.reseal(id, entry.expiresAt, values)
+	}
+
+	return &Session{ID: id, Values: values, ExpiresAt: entry.expiresAt}, nil
+}
+
+func (m *MemoryStore) Save(ctx context.Context, session *Session) error {
+	sealed, err := sealValues(m.keys, session.Values)
+	if err != nil {
+		return fmt.Errorf("sessions: encrypt session: %w", err)
+	}
+	m.mu.Lock()
+	m.sessions[session.ID] = &sealedEntry{expiresAt: session.ExpiresAt, sealed: sealed}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *MemoryStore) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	delete(m.sessions, id)
+	m.mu.Unlock()
+	return nil
+}
+
+// reseal replaces id's stored entry with values sealed under the current
+// primary key, e.g. after Get decrypted a value sealed under a retired key.
+func (m *MemoryStore) reseal(id string, expiresAt time.Time, values map[string]interface{}) {
+	sealed, err := sealValues(m.keys, values)
+	if err != nil {
+		return
+	}
+	m.mu.Lock()
+	m.sessions[id] = &sealedEntry{expiresAt: expiresAt, sealed: sealed}
+	m.mu.Unlock()
+}
@@ -0,0 +1,116 @@
+package sessions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKeySet(t *testing.T) *KeySet {
+	t.Helper()
+	k, err := GenerateKey("k1")
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return NewKeySet(k)
+}
+
+func TestMemoryStoreSaveGetRoundTrips(t *testing.T) {
+	store := NewMemoryStore(testKeySet(t))
+	ctx := context.Background()
+
+	session := &Session{
+		ID:        "sess-1",
+		Values:    map[string]interface{}{"refresh_token": "super-secret-token"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Get(ctx, "sess-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Values["refresh_token"] != "super-secret-token" {
+		t.Errorf("Values[refresh_token] = %v, want super-secret-token", got.Values["refresh_token"])
+	}
+}
+
+// TestMemoryStoreDoesNotHoldPlaintextValues is a regression test for
+// session payloads (including refresh tokens) being held in the clear:
+// the stored representation must not contain the plaintext value anywhere.
+func TestMemoryStoreDoesNotHoldPlaintextValues(t *testing.T) {
+	store := NewMemoryStore(testKeySet(t))
+	ctx := context.Background()
+
+	const secret = "super-secret-refresh-token-value"
+	session := &Session{
+		ID:        "sess-1",
+		Values:    map[string]interface{}{"refresh_token": secret},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	store.mu.RLock()
+	entry, ok := store.sessions["sess-1"]
+	store.mu.RUnlock()
+	if !ok {
+		t.Fatal("session not found in store")
+	}
+	if strings.Contains(entry.sealed, secret) {
+		t.Fatalf("stored entry contains the plaintext secret: %s", entry.sealed)
+	}
+}
+
+func TestMemoryStoreGetExpired(t *testing.T) {
+	store := NewMemoryStore(testKeySet(t))
+	ctx := context.Background()
+
+	session := &Session{
+		ID:        "sess-1",
+		Values:    map[string]interface{}{},
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := store.Get(ctx, "sess-1"); err != ErrNotFound {
+		t.Fatalf("Get expired session: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreGetReissuesAfterRotation(t *testing.T) {
+	keys := testKeySet(t)
+	store := NewMemoryStore(keys)
+	ctx := context.Background()
+
+	session := &Session{
+		ID:        "sess-1",
+		Values:    map[string]interface{}{"x": "y"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	k2, err := GenerateKey("k2")
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	keys.Rotate(k2)
+
+	if _, err := store.Get(ctx, "sess-1"); err != nil {
+		t.Fatalf("Get after rotation: %v", err)
+	}
+
+	store.mu.RLock()
+	entry := store.sessions["sess-1"]
+	store.mu.RUnlock()
+	if !strings.HasPrefix(entry.sealed, "k2"+sealedSeparator) {
+		t.Errorf("entry was not resealed under the new primary key: %s", entry.sealed)
+	}
+}
@@ -0,0 +1,99 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// FromContext returns the Session bound to ctx by Middleware, if any.
+func FromContext(ctx context.Context) (*Session, bool) {
+	s, ok := ctx.Value(contextKey{}).(*Session)
+	return s, ok
+}
+
+// Middleware loads the session referenced by an encrypted session ID cookie
+// named cookieName, creating one with ttl remaining validity if absent, and
+// binds it to the request context for downstream handlers to read via
+// FromContext. Mutations handlers make to the *Session returned by
+// FromContext are persisted to store after the handler returns.
+//
+// The cookie carries only the session ID, encrypted with keys so a client
+// cannot forge or enumerate IDs; the session payload itself lives in store.
+func Middleware(store Store, keys *KeySet, cookieName string, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, setCookie, err := loadOrCreate(r, store, keys, cookieName, ttl)
+			if err != nil {
+				http.Error(w, "session error", http.StatusInternalServerError)
+				return
+			}
+			if setCookie != "" {
+				http.SetCookie(w, &http.Cookie{
+					Name:     cookieName,
+					Value:    setCookie,
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   true,
+					SameSite: http.SameSiteLaxMode,
+					Expires:  session.ExpiresAt,
+				})
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, session)
+			next.ServeHTTP(w, r.WithContext(ctx))
+
+			_ = store.Save(r.Context(), session)
+		})
+	}
+}
+
+func loadOrCreate(r *http.Request, store Store, keys *KeySet, cookieName string, ttl time.Duration) (*Session, string, error) {
+	cookie, err := r.Cookie(cookieName)
+	if err != nil || cookie.Value == "" {
+		return newSession(r.Context(), store, keys, ttl)
+	}
+
+	idBytes, reissue, err := keys.Decrypt(cookie.Value)
+	if err != nil {
+		return newSession(r.Context(), store, keys, ttl)
+	}
+
+	session, err := store.Get(r.Context(), string(idBytes))
+	if errors.Is(err, ErrNotFound) {
+		return newSession(r.Context(), store, keys, ttl)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !reissue {
+		return session, "", nil
+	}
+	sealed, err := keys.Encrypt(idBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, sealed, nil
+}
+
+func newSession(ctx context.Context, store Store, keys *KeySet, ttl time.Duration) (*Session, string, error) {
+	session := &Session{
+		ID:        uuid.NewString(),
+		Values:    make(map[string]interface{}),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		return nil, "", err
+	}
+	sealed, err := keys.Encrypt([]byte(session.ID))
+	if err != nil {
+		return nil, "", err
+	}
+	return session, sealed, nil
+}
@@ -0,0 +1,127 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore persists sessions in Redis keyed by keyPrefix+ID, and relies on
+// Redis's native key expiry rather than a background reaper. Session.Values
+// is sealed with keys before being written, so compromising Redis alone
+// (a backup, a misconfigured ACL, a neighboring tenant) doesn't expose
+// session contents such as a stored refresh token.
+type RedisStore struct {
+	client    redis.Cmdable
+	keyPrefix string
+	keys      *KeySet
+}
+
+// redisEntry is the JSON envelope actually stored in Redis: ID and
+// ExpiresAt stay in the clear since the store needs them to key and expire
+// entries without decrypting every value, while Sealed holds the encrypted
+// Session.Values payload.
+type redisEntry struct {
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Sealed    string    `json:"sealed"`
+}
+
+// NewRedisStore constructs a RedisStore. keyPrefix is prepended to every
+// session ID to namespace keys (e.g. "janua:session:").
+func NewRedisStore(client redis.Cmdable, keyPrefix string, keys *KeySet) *RedisStore {
+	return &RedisStore{client: client, keyPrefix: keyPrefix, keys: keys}
+}
+
+func (r *RedisStore) key(id string) string { return r.keyPrefix + id }
+
+func (r *RedisStore) Get(ctx context.Context, id string) (*Session, error) {
+	raw, err := r.client.Get(ctx, r.key(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sessions: redis get: %w", err)
+	}
+
+	var entry redisEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("sessions: decode session: %w", err)
+	}
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+
+	values, reissue, err := openSealedValues(r.keys, entry.Sealed)
+	if err != nil {
+		return nil, fmt.Errorf("sessions: decrypt session: %w", err)
+	}
+	if reissue {
+		r.reseal(ctx, entry, values)
+	}
+
+	return &Session{ID: entry.ID, Values: values, ExpiresAt: entry.ExpiresAt}, nil
+}
+
+func (r *RedisStore) Save(ctx context.Context, session *Session) error {
+	ttl, err := r.ttl(session.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := sealValues(r.keys, session.Values)
+	if err != nil {
+		return fmt.Errorf("sessions: encrypt session: %w", err)
+	}
+
+	raw, err := json.Marshal(redisEntry{ID: session.ID, ExpiresAt: session.ExpiresAt, Sealed: sealed})
+	if err != nil {
+		return fmt.Errorf("sessions: encode session: %w", err)
+	}
+
+	if err := r.client.Set(ctx, r.key(session.ID), raw, ttl).Err(); err != nil {
+		return fmt.Errorf("sessions: redis set: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisStore) Delete(ctx context.Context, id string) error {
+	if err := r.client.Del(ctx, r.key(id)).Err(); err != nil {
+		return fmt.Errorf("sessions: redis del: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisStore) ttl(expiresAt time.Time) (time.Duration, error) {
+	if expiresAt.IsZero() {
+		return 0, nil
+	}
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return 0, fmt.Errorf("sessions: refusing to save an already-expired session")
+	}
+	return ttl, nil
+}
+
+// reseal replaces the stored entry with values sealed under the current
+// primary key, e.g. after Get decrypted a value sealed under a retired key.
+// It's best-effort: a failure here just means the next Get reissues again.
+func (r *RedisStore) reseal(ctx context.Context, entry redisEntry, values map[string]interface{}) {
+	ttl, err := r.ttl(entry.ExpiresAt)
+	if err != nil {
+		return
+	}
+	sealed, err := sealValues(r.keys, values)
+	if err != nil {
+		return
+	}
+	raw, err := json.Marshal(redisEntry{ID: entry.ID, ExpiresAt: entry.ExpiresAt, Sealed: sealed})
+	if err != nil {
+		return
+	}
+	_ = r.client.Set(ctx, r.key(entry.ID), raw, ttl).Err()
+}
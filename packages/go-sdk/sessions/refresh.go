@@ -0,0 +1,63 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenStore persists opaque refresh tokens server-side, handing the
+// caller only a random ID to place in an httpOnly cookie. This keeps the
+// actual refresh token off the browser entirely: stealing the ID cookie is
+// useless without also compromising the store, and a single Revoke call
+// invalidates it immediately regardless of the access token's remaining
+// lifetime. It satisfies the janua package's RefreshStore interface.
+type RefreshTokenStore struct {
+	store Store
+	ttl   time.Duration
+}
+
+// NewRefreshTokenStore wraps store, persisting refresh tokens for ttl.
+func NewRefreshTokenStore(store Store, ttl time.Duration) *RefreshTokenStore {
+	return &RefreshTokenStore{store: store, ttl: ttl}
+}
+
+// Save stores refreshToken under a freshly generated ID and returns it.
+func (s *RefreshTokenStore) Save(ctx context.Context, refreshToken string) (string, error) {
+	id := uuid.NewString()
+	session := &Session{
+		ID:        id,
+		Values:    map[string]interface{}{"refresh_token": refreshToken},
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+	if err := s.store.Save(ctx, session); err != nil {
+		return "", fmt.Errorf("sessions: save refresh token: %w", err)
+	}
+	return id, nil
+}
+
+// Redeem returns the refresh token for id and deletes it, so each ID is
+// usable exactly once (a refresh endpoint should issue a new ID alongside
+// every new access token).
+func (s *RefreshTokenStore) Redeem(ctx context.Context, id string) (string, error) {
+	session, err := s.store.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if err := s.store.Delete(ctx, id); err != nil {
+		return "", fmt.Errorf("sessions: delete redeemed refresh token: %w", err)
+	}
+	token, _ := session.Values["refresh_token"].(string)
+	if token == "" {
+		return "", fmt.Errorf("sessions: refresh token session %q has no refresh_token value", id)
+	}
+	return token, nil
+}
+
+// Revoke deletes the refresh token for id, e.g. on logout, without
+// redeeming it.
+func (s *RefreshTokenStore) Revoke(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
@@ -0,0 +1,17 @@
+package sessions
+
+import "time"
+
+// Session is the payload a Store persists. Values is intentionally a loose
+// map so callers can stash whatever they need (a refresh token, a CSRF
+// seed, user preferences) without the SDK dictating a schema.
+type Session struct {
+	ID        string
+	Values    map[string]interface{}
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the session has passed its expiry time.
+func (s *Session) Expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().After(s.ExpiresAt)
+}
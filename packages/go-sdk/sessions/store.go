@@ -0,0 +1,25 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Store.Get when no session exists for the
+// requested ID (or it has expired and been reaped).
+var ErrNotFound = errors.New("sessions: not found")
+
+// Store persists Sessions server-side, keyed by Session.ID. Implementations
+// must be safe for concurrent use.
+type Store interface {
+	// Get returns the session for id, or ErrNotFound.
+	Get(ctx context.Context, id string) (*Session, error)
+
+	// Save creates or overwrites the session, honoring its ExpiresAt for
+	// backends that support native TTLs.
+	Save(ctx context.Context, session *Session) error
+
+	// Delete removes the session for id. It is not an error for id to
+	// already be absent.
+	Delete(ctx context.Context, id string) error
+}
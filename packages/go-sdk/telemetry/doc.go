@@ -0,0 +1,14 @@
+// Package telemetry instruments SDK operations with Prometheus metrics and
+// OpenTelemetry tracing.
+//
+// Recorder is the shared instrumentation point used by the janua, oidc,
+// ldap, and realtime packages: JWT issuance and verification, OIDC code
+// exchanges, LDAP authentications, and the number of active WebSocket
+// connections go through the same counters, histograms, gauges, and spans
+// regardless of which package triggered them. Individual WebSocket messages
+// are not counted. A zero-value Recorder (or a nil *Recorder) is always safe
+// to use and simply records nothing, so instrumentation is opt-in: the
+// janua client constructor takes WithMeter, WithTracerProvider, and
+// WithMetricsRegistry, and the oidc, ldap, and realtime packages take a
+// Recorder built with NewRecorder through their WithRecorder options.
+package telemetry
@@ -0,0 +1,149 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/madfam-org/janua/packages/go-sdk"
+
+// Recorder is the shared instrumentation point for SDK operations. The zero
+// value and nil *Recorder are both valid and record nothing, so callers
+// that don't configure telemetry pay no cost beyond a nil check.
+type Recorder struct {
+	promAuthRequestsTotal *prometheus.CounterVec
+	promJWTVerifyDuration *prometheus.HistogramVec
+	promWSActiveConns     prometheus.Gauge
+
+	otelAuthRequestsTotal metric.Int64Counter
+	otelJWTVerifyDuration metric.Float64Histogram
+	otelWSActiveConns     metric.Int64UpDownCounter
+
+	tracer trace.Tracer
+}
+
+// NewRecorder builds a Recorder. reg and meter may each be nil to skip that
+// backend; tp may be nil to use the OpenTelemetry no-op tracer.
+func NewRecorder(reg prometheus.Registerer, meter metric.Meter, tp trace.TracerProvider) (*Recorder, error) {
+	r := &Recorder{}
+
+	if reg != nil {
+		r.promAuthRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "janua_auth_requests_total",
+			Help: "Total authentication requests handled by the Janua SDK, by provider and outcome.",
+		}, []string{"provider", "outcome"})
+		r.promJWTVerifyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "janua_jwt_verify_duration_seconds",
+			Help:    "Time taken to verify a JWT, in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"outcome"})
+		r.promWSActiveConns = prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "janua_ws_active_connections",
+			Help: "Number of currently connected realtime WebSocket clients.",
+		})
+		for _, c := range []prometheus.Collector{r.promAuthRequestsTotal, r.promJWTVerifyDuration, r.promWSActiveConns} {
+			if err := reg.Register(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if meter != nil {
+		var err error
+		r.otelAuthRequestsTotal, err = meter.Int64Counter("janua.auth.requests",
+			metric.WithDescription("Total authentication requests handled by the Janua SDK."))
+		if err != nil {
+			return nil, err
+		}
+		r.otelJWTVerifyDuration, err = meter.Float64Histogram("janua.jwt.verify.duration",
+			metric.WithDescription("Time taken to verify a JWT."), metric.WithUnit("s"))
+		if err != nil {
+			return nil, err
+		}
+		r.otelWSActiveConns, err = meter.Int64UpDownCounter("janua.ws.active_connections",
+			metric.WithDescription("Number of currently connected realtime WebSocket clients."))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tp != nil {
+		r.tracer = tp.Tracer(instrumentationName)
+	} else {
+		r.tracer = trace.NewNoopTracerProvider().Tracer(instrumentationName)
+	}
+
+	return r, nil
+}
+
+// ObserveAuthRequest records one authentication attempt against provider
+// (e.g. "jwt", "oidc", "ldap", "webauthn") with the given outcome (e.g.
+// "success", "denied", "error").
+func (r *Recorder) ObserveAuthRequest(provider, outcome string) {
+	if r == nil {
+		return
+	}
+	if r.promAuthRequestsTotal != nil {
+		r.promAuthRequestsTotal.WithLabelValues(provider, outcome).Inc()
+	}
+	if r.otelAuthRequestsTotal != nil {
+		r.otelAuthRequestsTotal.Add(context.Background(), 1,
+			metric.WithAttributes(attribute.String("provider", provider), attribute.String("outcome", outcome)))
+	}
+}
+
+// ObserveJWTVerifyDuration records how long a JWT verification took.
+func (r *Recorder) ObserveJWTVerifyDuration(outcome string, d time.Duration) {
+	if r == nil {
+		return
+	}
+	if r.promJWTVerifyDuration != nil {
+		r.promJWTVerifyDuration.WithLabelValues(outcome).Observe(d.Seconds())
+	}
+	if r.otelJWTVerifyDuration != nil {
+		r.otelJWTVerifyDuration.Record(context.Background(), d.Seconds(),
+			metric.WithAttributes(attribute.String("outcome", outcome)))
+	}
+}
+
+// IncWSActiveConnections increments the active realtime WebSocket
+// connection gauge.
+func (r *Recorder) IncWSActiveConnections() {
+	if r == nil {
+		return
+	}
+	if r.promWSActiveConns != nil {
+		r.promWSActiveConns.Inc()
+	}
+	if r.otelWSActiveConns != nil {
+		r.otelWSActiveConns.Add(context.Background(), 1)
+	}
+}
+
+// DecWSActiveConnections decrements the active realtime WebSocket
+// connection gauge.
+func (r *Recorder) DecWSActiveConnections() {
+	if r == nil {
+		return
+	}
+	if r.promWSActiveConns != nil {
+		r.promWSActiveConns.Dec()
+	}
+	if r.otelWSActiveConns != nil {
+		r.otelWSActiveConns.Add(context.Background(), -1)
+	}
+}
+
+// StartSpan starts a span named name, using the no-op tracer when no
+// TracerProvider was configured.
+func (r *Recorder) StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if r == nil || r.tracer == nil {
+		return trace.NewNoopTracerProvider().Tracer(instrumentationName).Start(ctx, name)
+	}
+	return r.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
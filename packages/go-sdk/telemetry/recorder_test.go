@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestNilRecorderIsSafe is a regression test for the package's core
+// contract: every caller (janua, oidc, ldap, realtime) calls these methods
+// on a possibly-nil *Recorder when telemetry isn't configured, and none of
+// them may panic.
+func TestNilRecorderIsSafe(t *testing.T) {
+	var r *Recorder
+
+	r.ObserveAuthRequest("jwt", "success")
+	r.ObserveJWTVerifyDuration("success", time.Millisecond)
+	r.IncWSActiveConnections()
+	r.DecWSActiveConnections()
+
+	ctx, span := r.StartSpan(context.Background(), "test.span")
+	if ctx == nil {
+		t.Fatal("StartSpan returned a nil context")
+	}
+	span.End()
+}
+
+func TestZeroValueRecorderIsSafe(t *testing.T) {
+	var r Recorder
+	r.ObserveAuthRequest("oidc", "error")
+	r.ObserveJWTVerifyDuration("error", time.Second)
+}
+
+func TestNewRecorderWithAllBackendsNil(t *testing.T) {
+	r, err := NewRecorder(nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	if r == nil {
+		t.Fatal("NewRecorder returned a nil Recorder")
+	}
+	r.ObserveAuthRequest("ldap", "success")
+}
+
+func TestNewRecorderRegistersPrometheusCollectors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r, err := NewRecorder(reg, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	r.ObserveAuthRequest("jwt", "success")
+
+	metrics, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	var found bool
+	for _, mf := range metrics {
+		if mf.GetName() == "janua_auth_requests_total" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("janua_auth_requests_total was not registered/recorded")
+	}
+}
+
+func TestNewRecorderDuplicateRegistrationFails(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := NewRecorder(reg, nil, nil); err != nil {
+		t.Fatalf("first NewRecorder: %v", err)
+	}
+	if _, err := NewRecorder(reg, nil, nil); err == nil {
+		t.Fatal("second NewRecorder with the same registry: expected a duplicate-registration error")
+	}
+}
@@ -0,0 +1,69 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	flagUserPresent   = 1 << 0
+	flagUserVerified  = 1 << 2
+	flagAttestedData  = 1 << 6
+	flagExtensionData = 1 << 7
+)
+
+// authenticatorData is the parsed form of the authData byte string present
+// in both attestation objects and assertions (WebAuthn spec §6.1).
+type authenticatorData struct {
+	RPIDHash               []byte
+	Flags                  byte
+	SignCount              uint32
+	AAGUID                 []byte
+	CredentialID           []byte
+	CredentialPublicKeyRaw []byte
+}
+
+func (a authenticatorData) userPresent() bool  { return a.Flags&flagUserPresent != 0 }
+func (a authenticatorData) userVerified() bool { return a.Flags&flagUserVerified != 0 }
+func (a authenticatorData) hasAttestedData() bool {
+	return a.Flags&flagAttestedData != 0
+}
+
+// parseAuthenticatorData parses the fixed-size header and, when present,
+// the variable-length attested credential data block. It does not attempt
+// to parse trailing extension data.
+func parseAuthenticatorData(raw []byte) (authenticatorData, error) {
+	var out authenticatorData
+	if len(raw) < 37 {
+		return out, fmt.Errorf("webauthn: authData too short")
+	}
+	out.RPIDHash = raw[0:32]
+	out.Flags = raw[32]
+	out.SignCount = binary.BigEndian.Uint32(raw[33:37])
+
+	rest := raw[37:]
+	if !out.hasAttestedData() {
+		return out, nil
+	}
+	if len(rest) < 16+2 {
+		return out, fmt.Errorf("webauthn: attested credential data truncated")
+	}
+	out.AAGUID = rest[0:16]
+	credIDLen := binary.BigEndian.Uint16(rest[16:18])
+	rest = rest[18:]
+	if uint16(len(rest)) < credIDLen {
+		return out, fmt.Errorf("webauthn: credential id truncated")
+	}
+	out.CredentialID = rest[:credIDLen]
+	rest = rest[credIDLen:]
+
+	// The remainder, up to any extension data, is the CBOR-encoded COSE
+	// public key. Decoding it consumes exactly the key bytes, so whatever
+	// decodeCBOR leaves over is extension data we don't need.
+	keyEnd := len(rest)
+	if _, tail, err := decodeCBOR(rest); err == nil {
+		keyEnd = len(rest) - len(tail)
+	}
+	out.CredentialPublicKeyRaw = rest[:keyEnd]
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package webauthn
+
+import "fmt"
+
+// decodeCBOR is a minimal CBOR decoder covering the subset used by WebAuthn
+// attestation objects and COSE keys: unsigned/negative integers, byte
+// strings, text strings, arrays, and maps. It intentionally does not
+// support floats, tags, or indefinite-length items; a full CBOR
+// implementation is out of scope for the SDK.
+func decodeCBOR(b []byte) (interface{}, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, fmt.Errorf("webauthn: cbor: unexpected end of input")
+	}
+
+	major := b[0] >> 5
+	info := b[0] & 0x1f
+	rest := b[1:]
+
+	length, rest, err := cborLength(info, rest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0: // unsigned int
+		return length, rest, nil
+	case 1: // negative int
+		return -1 - int64(length), rest, nil
+	case 2: // byte string
+		if uint64(len(rest)) < length {
+			return nil, nil, fmt.Errorf("webauthn: cbor: byte string truncated")
+		}
+		return append([]byte(nil), rest[:length]...), rest[length:], nil
+	case 3: // text string
+		if uint64(len(rest)) < length {
+			return nil, nil, fmt.Errorf("webauthn: cbor: text string truncated")
+		}
+		return string(rest[:length]), rest[length:], nil
+	case 4: // array
+		// Every element consumes at least one byte, so a length that
+		// exceeds the remaining input can never be satisfied. Reject it
+		// before allocating, rather than letting an attacker-controlled
+		// length (up to 2^64-1 for a 9-byte header) drive a multi-exabyte
+		// make() that panics the process.
+		if length > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("webauthn: cbor: array length %d exceeds remaining input", length)
+		}
+		items := make([]interface{}, 0, length)
+		for i := uint64(0); i < length; i++ {
+			var item interface{}
+			item, rest, err = decodeCBOR(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, rest, nil
+	case 5: // map
+		// Same reasoning as the array case, except each entry consumes at
+		// least two bytes (a key and a value).
+		if length > uint64(len(rest))/2 {
+			return nil, nil, fmt.Errorf("webauthn: cbor: map length %d exceeds remaining input", length)
+		}
+		m := make(map[interface{}]interface{}, length)
+		for i := uint64(0); i < length; i++ {
+			var key, val interface{}
+			key, rest, err = decodeCBOR(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+			val, rest, err = decodeCBOR(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key] = val
+		}
+		return m, rest, nil
+	default:
+		return nil, nil, fmt.Errorf("webauthn: cbor: unsupported major type %d", major)
+	}
+}
+
+func cborLength(info byte, b []byte) (uint64, []byte, error) {
+	switch {
+	case info < 24:
+		return uint64(info), b, nil
+	case info == 24:
+		if len(b) < 1 {
+			return 0, nil, fmt.Errorf("webauthn: cbor: truncated length")
+		}
+		return uint64(b[0]), b[1:], nil
+	case info == 25:
+		if len(b) < 2 {
+			return 0, nil, fmt.Errorf("webauthn: cbor: truncated length")
+		}
+		return uint64(b[0])<<8 | uint64(b[1]), b[2:], nil
+	case info == 26:
+		if len(b) < 4 {
+			return 0, nil, fmt.Errorf("webauthn: cbor: truncated length")
+		}
+		var v uint64
+		for _, c := range b[:4] {
+			v = v<<8 | uint64(c)
+		}
+		return v, b[4:], nil
+	case info == 27:
+		if len(b) < 8 {
+			return 0, nil, fmt.Errorf("webauthn: cbor: truncated length")
+		}
+		var v uint64
+		for _, c := range b[:8] {
+			v = v<<8 | uint64(c)
+		}
+		return v, b[8:], nil
+	default:
+		return 0, nil, fmt.Errorf("webauthn: cbor: indefinite-length items are not supported")
+	}
+}
@@ -0,0 +1,59 @@
+package webauthn
+
+import "testing"
+
+// TestDecodeCBORRejectsOversizedLengths is a regression test for an
+// unbounded-allocation panic: an attacker-controlled array/map length
+// (e.g. from an unauthenticated FinishRegistration call) used to drive a
+// make() sized directly from the wire before any byte was available to
+// back it, crashing the process with "makeslice: cap out of range"
+// instead of returning an error.
+func TestDecodeCBORRejectsOversizedLengths(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{
+			name: "array length far exceeds remaining input",
+			// major type 4 (array), 8-byte length follows (info 27),
+			// length = 0xFFFFFFFFFFFFFFFF, zero bytes of actual content.
+			in: []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			name: "map length far exceeds remaining input",
+			// major type 5 (map), same oversized 8-byte length.
+			in: []byte{0xbb, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeCBOR(tt.in)
+			if err == nil {
+				t.Fatal("decodeCBOR: expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestDecodeCBORDecodesArray(t *testing.T) {
+	// [1, "ab"] encoded as CBOR: array(2), unsigned(1), text(2) "ab".
+	in := []byte{0x82, 0x01, 0x62, 'a', 'b'}
+	v, rest, err := decodeCBOR(in)
+	if err != nil {
+		t.Fatalf("decodeCBOR: %v", err)
+	}
+	if len(rest) != 0 {
+		t.Fatalf("leftover input: %v", rest)
+	}
+	items, ok := v.([]interface{})
+	if !ok || len(items) != 2 {
+		t.Fatalf("decoded = %#v, want a 2-element array", v)
+	}
+	if items[0] != uint64(1) {
+		t.Errorf("items[0] = %#v, want uint64(1)", items[0])
+	}
+	if items[1] != "ab" {
+		t.Errorf("items[1] = %#v, want \"ab\"", items[1])
+	}
+}
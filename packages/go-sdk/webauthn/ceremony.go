@@ -0,0 +1,326 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrVerification wraps any failed verification check on an attestation or
+// assertion so callers can distinguish it from configuration/store errors.
+// Input that cannot be decoded at all (malformed clientDataJSON,
+// attestation objects, authenticator data or COSE keys) and unsupported key
+// types or algorithms are reported without it.
+var ErrVerification = errors.New("webauthn: verification failed")
+
+// challengeTTL bounds how long a Begin* challenge remains redeemable.
+const challengeTTL = 5 * time.Minute
+
+// SessionIssuer mints a Janua session token once a ceremony succeeds. It is
+// satisfied by *janua.Client.
+type SessionIssuer interface {
+	IssueToken(subject string, roles []string, amr []string) (string, error)
+}
+
+// WebAuthn orchestrates registration and login ceremonies for a single
+// relying party.
+type WebAuthn struct {
+	rp       RelyingParty
+	store    CredentialStore
+	sessions SessionIssuer
+
+	mu         sync.Mutex
+	challenges map[string]pendingChallenge
+}
+
+type pendingChallenge struct {
+	userHandle []byte
+	expiresAt  time.Time
+}
+
+// New constructs a WebAuthn ceremony handler for rp, persisting credentials
+// via store and minting session tokens via sessions on successful login.
+func New(rp RelyingParty, store CredentialStore, sessions SessionIssuer) (*WebAuthn, error) {
+	if err := rp.validate(); err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, fmt.Errorf("webauthn: CredentialStore is required")
+	}
+	return &WebAuthn{
+		rp:         rp,
+		store:      store,
+		sessions:   sessions,
+		challenges: make(map[string]pendingChallenge),
+	}, nil
+}
+
+// CredentialCreationOptions is the subset of
+// PublicKeyCredentialCreationOptions the SDK fills in; callers serialize it
+// to JSON and hand it to navigator.credentials.create() on the client.
+type CredentialCreationOptions struct {
+	Challenge            string                    `json:"challenge"`
+	RPID                 string                    `json:"rpId"`
+	RPName               string                    `json:"rpName"`
+	UserHandle           string                    `json:"userHandle"`
+	UserName             string                    `json:"userName"`
+	UserDisplayName      string                    `json:"userDisplayName"`
+	ResidentKey          bool                      `json:"residentKey"`
+	AllowedAttachments   []AuthenticatorAttachment `json:"allowedAttachments,omitempty"`
+	ExcludeCredentialIDs []string                  `json:"excludeCredentialIds,omitempty"`
+}
+
+// BeginRegistration issues a fresh challenge for userHandle and returns the
+// options the client needs to call navigator.credentials.create().
+func (w *WebAuthn) BeginRegistration(userHandle []byte, userName, userDisplayName string) (CredentialCreationOptions, error) {
+	challenge, err := randomChallenge()
+	if err != nil {
+		return CredentialCreationOptions{}, err
+	}
+	w.putChallenge(challenge, userHandle)
+
+	existing, err := w.store.CredentialsByUser(userHandle)
+	if err != nil {
+		return CredentialCreationOptions{}, fmt.Errorf("webauthn: list existing credentials: %w", err)
+	}
+	exclude := make([]string, 0, len(existing))
+	for _, c := range existing {
+		exclude = append(exclude, encodeBase64URL(c.ID))
+	}
+
+	return CredentialCreationOptions{
+		Challenge:            encodeBase64URL(challenge),
+		RPID:                 w.rp.RPID,
+		RPName:               w.rp.RPDisplayName,
+		UserHandle:           encodeBase64URL(userHandle),
+		UserName:             userName,
+		UserDisplayName:      userDisplayName,
+		ResidentKey:          w.rp.RequireResidentKey,
+		AllowedAttachments:   w.rp.AllowedAttachments,
+		ExcludeCredentialIDs: exclude,
+	}, nil
+}
+
+// AttestationResponse is the AuthenticatorAttestationResponse returned by
+// navigator.credentials.create(), already base64url-decoded by the caller's
+// transport layer into raw bytes.
+type AttestationResponse struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AttestationObject []byte
+	Transports        []string
+}
+
+// FinishRegistration verifies resp against the challenge previously issued
+// for userHandle and, on success, persists the resulting credential.
+func (w *WebAuthn) FinishRegistration(userHandle []byte, resp AttestationResponse) (Credential, error) {
+	var cred Credential
+
+	if _, err := w.takeClientData(resp.ClientDataJSON, "webauthn.create", userHandle); err != nil {
+		return cred, err
+	}
+
+	att, err := parseAttestationObject(resp.AttestationObject)
+	if err != nil {
+		return cred, err
+	}
+	if err := verifyRPIDHash(att.AuthData, w.rp); err != nil {
+		return cred, fmt.Errorf("%w: %s", ErrVerification, err)
+	}
+	if !att.AuthData.userPresent() {
+		return cred, fmt.Errorf("%w: user presence flag not set", ErrVerification)
+	}
+	if !att.AuthData.hasAttestedData() {
+		return cred, fmt.Errorf("%w: attestation object has no attested credential data", ErrVerification)
+	}
+
+	// Attestation statement (attStmt) signature/chain verification against
+	// vendor roots is intentionally not performed here: most deployments
+	// accept "none"/self attestation and rely on authData integrity plus
+	// TLS/origin binding, which is enforced above and in parseClientData.
+
+	cred = Credential{
+		ID:           att.AuthData.CredentialID,
+		UserHandle:   userHandle,
+		PublicKey:    att.AuthData.CredentialPublicKeyRaw,
+		AAGUID:       att.AuthData.AAGUID,
+		SignCount:    att.AuthData.SignCount,
+		Transports:   resp.Transports,
+		Discoverable: w.rp.RequireResidentKey,
+	}
+	if err := w.store.SaveCredential(userHandle, cred); err != nil {
+		return Credential{}, fmt.Errorf("webauthn: save credential: %w", err)
+	}
+	return cred, nil
+}
+
+// CredentialRequestOptions mirrors PublicKeyCredentialRequestOptions.
+type CredentialRequestOptions struct {
+	Challenge          string   `json:"challenge"`
+	RPID               string   `json:"rpId"`
+	AllowCredentialIDs []string `json:"allowCredentialIds,omitempty"`
+	UserVerification   string   `json:"userVerification"`
+}
+
+// BeginLogin issues a fresh challenge for a login ceremony. When userHandle
+// is nil the resulting options omit an allow-list, enabling discoverable
+// credential (resident key) logins where the authenticator itself supplies
+// the user handle.
+func (w *WebAuthn) BeginLogin(userHandle []byte) (CredentialRequestOptions, error) {
+	challenge, err := randomChallenge()
+	if err != nil {
+		return CredentialRequestOptions{}, err
+	}
+	w.putChallenge(challenge, userHandle)
+
+	opts := CredentialRequestOptions{
+		Challenge:        encodeBase64URL(challenge),
+		RPID:             w.rp.RPID,
+		UserVerification: "preferred",
+	}
+	if userHandle != nil {
+		creds, err := w.store.CredentialsByUser(userHandle)
+		if err != nil {
+			return CredentialRequestOptions{}, fmt.Errorf("webauthn: list existing credentials: %w", err)
+		}
+		for _, c := range creds {
+			opts.AllowCredentialIDs = append(opts.AllowCredentialIDs, encodeBase64URL(c.ID))
+		}
+	}
+	return opts, nil
+}
+
+// AssertionResponse is the AuthenticatorAssertionResponse returned by
+// navigator.credentials.get().
+type AssertionResponse struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+// LoginResult carries the verified credential and, when a SessionIssuer was
+// configured, the minted Janua session token.
+type LoginResult struct {
+	Credential   Credential
+	SessionToken string
+}
+
+// FinishLogin verifies resp against the pending challenge, enforces the
+// sign-count anti-cloning check, and on success mints a session token via
+// the configured SessionIssuer.
+func (w *WebAuthn) FinishLogin(resp AssertionResponse, roles []string) (LoginResult, error) {
+	var result LoginResult
+
+	cred, err := w.store.GetCredential(resp.CredentialID)
+	if err != nil {
+		return result, fmt.Errorf("webauthn: lookup credential: %w", err)
+	}
+
+	userHandle := resp.UserHandle
+	if userHandle == nil {
+		userHandle = cred.UserHandle
+	}
+	if _, err := w.takeClientData(resp.ClientDataJSON, "webauthn.get", userHandle); err != nil {
+		return result, err
+	}
+
+	authData, err := parseAuthenticatorData(resp.AuthenticatorData)
+	if err != nil {
+		return result, err
+	}
+	if err := verifyRPIDHash(authData, w.rp); err != nil {
+		return result, fmt.Errorf("%w: %s", ErrVerification, err)
+	}
+	if !authData.userPresent() {
+		return result, fmt.Errorf("%w: user presence flag not set", ErrVerification)
+	}
+	if !bytes.Equal(cred.UserHandle, userHandle) {
+		return result, fmt.Errorf("%w: user handle does not match stored credential", ErrVerification)
+	}
+
+	pub, alg, err := parseCOSEPublicKey(cred.PublicKey)
+	if err != nil {
+		return result, err
+	}
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	signedData := append(append([]byte(nil), resp.AuthenticatorData...), clientDataHash[:]...)
+	if err := verifySignature(pub, alg, signedData, resp.Signature); err != nil {
+		return result, err
+	}
+
+	// Counters of 0 mean the authenticator doesn't implement one; only
+	// enforce monotonicity when either side has reported a nonzero value.
+	if authData.SignCount != 0 || cred.SignCount != 0 {
+		if authData.SignCount <= cred.SignCount {
+			return result, fmt.Errorf("%w: sign count did not increase, possible cloned authenticator", ErrVerification)
+		}
+	}
+	if err := w.store.UpdateSignCount(resp.CredentialID, authData.SignCount); err != nil {
+		return result, fmt.Errorf("webauthn: update sign count: %w", err)
+	}
+	cred.SignCount = authData.SignCount
+	result.Credential = cred
+
+	if w.sessions != nil {
+		token, err := w.sessions.IssueToken(string(userHandle), roles, []string{"webauthn"})
+		if err != nil {
+			return result, fmt.Errorf("webauthn: issue session token: %w", err)
+		}
+		result.SessionToken = token
+	}
+	return result, nil
+}
+
+func (w *WebAuthn) putChallenge(challenge, userHandle []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.challenges[encodeBase64URL(challenge)] = pendingChallenge{
+		userHandle: userHandle,
+		expiresAt:  time.Now().Add(challengeTTL),
+	}
+}
+
+// takeClientData validates and consumes (single-use) the challenge embedded
+// in rawClientData.
+func (w *WebAuthn) takeClientData(rawClientData []byte, wantType string, userHandle []byte) (clientDataJSON, error) {
+	// Peek the challenge without validating origin/type yet, so we can
+	// look up and remove the pending entry first.
+	var peek clientDataJSON
+	if err := json.Unmarshal(rawClientData, &peek); err != nil {
+		return clientDataJSON{}, fmt.Errorf("webauthn: invalid clientDataJSON: %w", err)
+	}
+
+	w.mu.Lock()
+	pending, ok := w.challenges[peek.Challenge]
+	if ok {
+		delete(w.challenges, peek.Challenge)
+	}
+	w.mu.Unlock()
+
+	if !ok {
+		return clientDataJSON{}, fmt.Errorf("%w: unknown or already-used challenge", ErrVerification)
+	}
+	if time.Now().After(pending.expiresAt) {
+		return clientDataJSON{}, fmt.Errorf("%w: challenge expired", ErrVerification)
+	}
+	if pending.userHandle != nil && userHandle != nil && !bytes.Equal(pending.userHandle, userHandle) {
+		return clientDataJSON{}, fmt.Errorf("%w: challenge was not issued for this user", ErrVerification)
+	}
+
+	return parseClientData(rawClientData, wantType, peek.Challenge, w.rp)
+}
+
+func randomChallenge() ([]byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("webauthn: generate challenge: %w", err)
+	}
+	return b, nil
+}
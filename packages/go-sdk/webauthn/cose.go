@@ -0,0 +1,114 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers we support, per the IANA COSE registry.
+const (
+	coseAlgES256 = -7
+	coseAlgRS256 = -257
+)
+
+// COSE key common parameter labels (RFC 9053 §7).
+const (
+	coseKeyType = 1
+	coseKeyAlg  = 3
+)
+
+const (
+	coseKTYEC2 = 2
+	coseKTYRSA = 3
+)
+
+// EC2 key-specific labels.
+const (
+	coseEC2Curve = -1
+	coseEC2X     = -2
+	coseEC2Y     = -3
+)
+
+// RSA key-specific labels.
+const (
+	coseRSAN = -1
+	coseRSAE = -2
+)
+
+// parseCOSEPublicKey decodes a COSE_Key encoded as CBOR and returns a
+// standard library crypto.PublicKey along with its declared algorithm.
+func parseCOSEPublicKey(raw []byte) (interface{}, int64, error) {
+	decoded, _, err := decodeCBOR(raw)
+	if err != nil {
+		return nil, 0, fmt.Errorf("webauthn: decode COSE key: %w", err)
+	}
+	m, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, fmt.Errorf("webauthn: COSE key is not a CBOR map")
+	}
+
+	kty, _ := m[int64(coseKeyType)].(int64)
+	alg, _ := m[int64(coseKeyAlg)].(int64)
+
+	switch kty {
+	case coseKTYEC2:
+		x, _ := m[int64(coseEC2X)].([]byte)
+		y, _ := m[int64(coseEC2Y)].([]byte)
+		if len(x) == 0 || len(y) == 0 {
+			return nil, 0, fmt.Errorf("webauthn: EC2 key missing coordinates")
+		}
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		return pub, alg, nil
+	case coseKTYRSA:
+		n, _ := m[int64(coseRSAN)].([]byte)
+		e, _ := m[int64(coseRSAE)].([]byte)
+		if len(n) == 0 || len(e) == 0 {
+			return nil, 0, fmt.Errorf("webauthn: RSA key missing modulus/exponent")
+		}
+		pub := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return pub, alg, nil
+	default:
+		return nil, 0, fmt.Errorf("webauthn: unsupported COSE key type %d", kty)
+	}
+}
+
+// verifySignature checks sig over signedData using pub, dispatching on the
+// declared COSE algorithm.
+func verifySignature(pub interface{}, alg int64, signedData, sig []byte) error {
+	switch alg {
+	case coseAlgES256:
+		key, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("webauthn: ES256 signature requires an EC2 key")
+		}
+		digest := sha256.Sum256(signedData)
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return fmt.Errorf("webauthn: %w: ES256 signature invalid", ErrVerification)
+		}
+		return nil
+	case coseAlgRS256:
+		key, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("webauthn: RS256 signature requires an RSA key")
+		}
+		digest := sha256.Sum256(signedData)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("webauthn: %w: RS256 signature invalid", ErrVerification)
+		}
+		return nil
+	default:
+		return fmt.Errorf("webauthn: unsupported signature algorithm %d", alg)
+	}
+}
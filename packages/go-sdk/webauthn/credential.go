@@ -0,0 +1,57 @@
+package webauthn
+
+// Credential is the authenticator state Janua persists after a successful
+// registration ceremony, and consults on every subsequent login.
+type Credential struct {
+	// ID is the credential ID returned by the authenticator, used to
+	// look the credential back up on login.
+	ID []byte
+
+	// UserHandle is the opaque user ID the credential was registered
+	// against (the "user.id" of PublicKeyCredentialCreationOptions).
+	UserHandle []byte
+
+	// PublicKey is the COSE-encoded public key extracted from the
+	// attested credential data.
+	PublicKey []byte
+
+	// AAGUID identifies the authenticator model that generated the
+	// credential.
+	AAGUID []byte
+
+	// SignCount is the authenticator's signature counter as of the last
+	// verified ceremony. Logins must present a strictly greater value
+	// (when the authenticator implements counters at all) or the
+	// credential is considered cloned and the assertion is rejected.
+	SignCount uint32
+
+	// Transports records the transports the authenticator advertised at
+	// registration time (e.g. "usb", "nfc", "ble", "internal", "hybrid").
+	// BeginLogin's allow-list carries credential IDs only, not transports.
+	Transports []string
+
+	// Discoverable indicates the credential is a resident/discoverable
+	// key, meaning it can be used in a login ceremony without the server
+	// first sending an allow-list.
+	Discoverable bool
+}
+
+// CredentialStore persists WebAuthn credentials. Implementations must be
+// safe for concurrent use.
+type CredentialStore interface {
+	// SaveCredential stores a newly registered credential for userHandle.
+	SaveCredential(userHandle []byte, cred Credential) error
+
+	// GetCredential looks up a previously stored credential by its
+	// credential ID.
+	GetCredential(credentialID []byte) (Credential, error)
+
+	// CredentialsByUser returns every credential registered for
+	// userHandle, used to build allowCredentials on non-discoverable
+	// logins.
+	CredentialsByUser(userHandle []byte) ([]Credential, error)
+
+	// UpdateSignCount persists the new signature counter for credentialID
+	// after a successful assertion verification.
+	UpdateSignCount(credentialID []byte, signCount uint32) error
+}
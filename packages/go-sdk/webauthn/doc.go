@@ -0,0 +1,8 @@
+// Package webauthn implements WebAuthn/FIDO2 passwordless and second-factor
+// authentication ceremonies (registration and login) on top of the Janua
+// SDK, modelled on the ceremony shape of github.com/go-webauthn/webauthn.
+//
+// A successful FinishLogin mints a Janua session token via the SessionIssuer
+// supplied to New, so callers get a normal Janua session out of a WebAuthn
+// assertion without having to glue the two flows together themselves.
+package webauthn
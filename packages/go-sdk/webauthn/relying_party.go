@@ -0,0 +1,57 @@
+package webauthn
+
+import "fmt"
+
+// AuthenticatorAttachment restricts which class of authenticator may be
+// used during registration.
+type AuthenticatorAttachment string
+
+const (
+	AttachmentAny           AuthenticatorAttachment = ""
+	AttachmentPlatform      AuthenticatorAttachment = "platform"
+	AttachmentCrossPlatform AuthenticatorAttachment = "cross-platform"
+)
+
+// RelyingParty holds the RP configuration shared by every ceremony.
+type RelyingParty struct {
+	// RPID is the relying party identifier, normally the effective
+	// domain of the application (e.g. "example.com").
+	RPID string
+
+	// RPDisplayName is shown to the user by the authenticator/browser UI.
+	RPDisplayName string
+
+	// Origins is the set of origins (scheme+host+port) from which
+	// ceremonies may be initiated. An attestation or assertion whose
+	// clientData origin is not in this list is rejected.
+	Origins []string
+
+	// AllowedAttachments restricts which authenticator attachments may
+	// register. Empty means any attachment is accepted.
+	AllowedAttachments []AuthenticatorAttachment
+
+	// RequireResidentKey requests a discoverable/resident credential at
+	// registration so the authenticator can be used without a preceding
+	// username step, and marks credentials registered under it as
+	// Discoverable. Login ceremonies do not check it.
+	RequireResidentKey bool
+}
+
+func (rp RelyingParty) validate() error {
+	if rp.RPID == "" {
+		return fmt.Errorf("webauthn: RPID is required")
+	}
+	if len(rp.Origins) == 0 {
+		return fmt.Errorf("webauthn: at least one origin is required")
+	}
+	return nil
+}
+
+func (rp RelyingParty) originAllowed(origin string) bool {
+	for _, o := range rp.Origins {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}